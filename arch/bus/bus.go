@@ -0,0 +1,134 @@
+// Package bus provides a system bus that dispatches memory accesses of a CPU
+// to memory mapped devices based on address ranges.
+package bus
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Device is a memory mapped device. The address that is passed to the device
+// is relative to the start of the mapped address range.
+type Device interface {
+	Read(address uint16) uint8
+	Write(address uint16, value uint8)
+}
+
+// Bus dispatches reads and writes to the device that is mapped at the
+// address. It implements the basic memory interface of the CPU packages.
+// Reading an address that no device is mapped to returns the last value
+// that was transferred on the bus, emulating open bus behavior.
+type Bus struct {
+	mappings []*mapping
+	lookup   []uint16 // index+1 of the mapping for every address, 0 means unmapped
+	lastData uint8
+}
+
+type mapping struct {
+	device   Device
+	start    uint16
+	mirror   uint32 // size of the mirrored area, 0 for no mirroring
+	priority int
+}
+
+// MapOption defines an option for mapping a device.
+type MapOption func(*mapping)
+
+// WithPriority sets the priority of the mapping. If address ranges of mappings
+// overlap, the mapping with the higher priority handles the access. For equal
+// priorities, the last mapped device handles the access. The default priority is 0.
+func WithPriority(priority int) MapOption {
+	return func(m *mapping) {
+		m.priority = priority
+	}
+}
+
+// WithMirror mirrors the device every size bytes in the mapped address range,
+// the device gets passed the relative address modulo the size. This is useful
+// for devices that are not fully address decoded, like RAM that is mapped to
+// a larger address range than its size.
+func WithMirror(size int) MapOption {
+	return func(m *mapping) {
+		m.mirror = uint32(size)
+	}
+}
+
+// New returns a new bus without any mapped devices.
+func New() *Bus {
+	return &Bus{
+		lookup: make([]uint16, 0x10000),
+	}
+}
+
+// Map maps the device to the inclusive address range from start to end.
+func (b *Bus) Map(start, end uint16, device Device, options ...MapOption) error {
+	if end < start {
+		return fmt.Errorf("invalid address range 0x%04X-0x%04X", start, end)
+	}
+	if device == nil {
+		return errors.New("device is nil")
+	}
+	if len(b.mappings) == math.MaxUint16 {
+		return errors.New("maximum number of mappings reached")
+	}
+
+	m := &mapping{
+		device: device,
+		start:  start,
+	}
+	for _, option := range options {
+		option(m)
+	}
+
+	b.mappings = append(b.mappings, m)
+	index := uint16(len(b.mappings))
+
+	for address := uint32(start); address <= uint32(end); address++ {
+		current := b.lookup[address]
+		if current != 0 && b.mappings[current-1].priority > m.priority {
+			continue
+		}
+		b.lookup[address] = index
+	}
+	return nil
+}
+
+// Read reads a byte from the device mapped at the address.
+func (b *Bus) Read(address uint16) uint8 {
+	index := b.lookup[address]
+	if index == 0 {
+		return b.lastData
+	}
+
+	m := b.mappings[index-1]
+	b.lastData = m.device.Read(m.relative(address))
+	return b.lastData
+}
+
+// Write writes a byte to the device mapped at the address.
+func (b *Bus) Write(address uint16, value uint8) {
+	b.lastData = value
+
+	index := b.lookup[address]
+	if index == 0 {
+		return
+	}
+
+	m := b.mappings[index-1]
+	m.device.Write(m.relative(address), value)
+}
+
+// OpenBus returns the last value that was transferred on the bus.
+func (b *Bus) OpenBus() uint8 {
+	return b.lastData
+}
+
+// relative returns the address relative to the start of the mapping.
+func (m *mapping) relative(address uint16) uint16 {
+	offset := uint32(address - m.start)
+	if m.mirror != 0 {
+		offset %= m.mirror
+	}
+	return uint16(offset)
+}
@@ -0,0 +1,59 @@
+package bus
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestBusMirror(t *testing.T) {
+	b := New()
+	ram := NewRAM(0x800)
+	assert.NoError(t, b.Map(0x0000, 0x1FFF, ram, WithMirror(0x800)))
+
+	b.Write(0x0801, 0x12)
+	assert.Equal(t, 0x12, ram[1])
+	assert.Equal(t, 0x12, b.Read(0x0001))
+	assert.Equal(t, 0x12, b.Read(0x1801))
+}
+
+func TestBusPriority(t *testing.T) {
+	b := New()
+	low := NewRAM(0x100)
+	high := NewRAM(0x10)
+	assert.NoError(t, b.Map(0x0000, 0x00FF, low))
+	assert.NoError(t, b.Map(0x0010, 0x001F, high, WithPriority(1)))
+	assert.NoError(t, b.Map(0x0000, 0x00FF, low))
+
+	b.Write(0x0011, 1)
+	assert.Equal(t, 1, high[1])
+	assert.Equal(t, 0, low[0x11])
+
+	b.Write(0x0021, 2)
+	assert.Equal(t, 2, low[0x21])
+}
+
+func TestBusOpenBus(t *testing.T) {
+	b := New()
+	var written []uint16
+	device := DeviceFuncs{
+		ReadFunc: func(address uint16) uint8 { return uint8(address) },
+		WriteFunc: func(address uint16, _ uint8) {
+			written = append(written, address)
+		},
+	}
+	assert.NoError(t, b.Map(0x4000, 0x4017, device))
+
+	assert.Equal(t, 0x05, b.Read(0x4005))
+	assert.Equal(t, 0x05, b.Read(0x5000))
+
+	b.Write(0x4010, 0x80)
+	assert.Equal(t, []uint16{0x10}, written)
+	assert.Equal(t, 0x80, b.OpenBus())
+}
+
+func TestBusMapErrors(t *testing.T) {
+	b := New()
+	assert.Error(t, b.Map(2, 1, NewRAM(1)), "invalid address range 0x0002-0x0001")
+	assert.Error(t, b.Map(0, 1, nil), "device is nil")
+}
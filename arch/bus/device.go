@@ -0,0 +1,61 @@
+package bus
+
+// RAM is a read and write memory device.
+type RAM []byte
+
+// NewRAM returns a new RAM device of the given size.
+func NewRAM(size int) RAM {
+	return make(RAM, size)
+}
+
+// Read reads a byte from the RAM, reads outside of the RAM return 0.
+func (r RAM) Read(address uint16) uint8 {
+	if int(address) >= len(r) {
+		return 0
+	}
+	return r[address]
+}
+
+// Write writes a byte to the RAM, writes outside of the RAM are ignored.
+func (r RAM) Write(address uint16, value uint8) {
+	if int(address) >= len(r) {
+		return
+	}
+	r[address] = value
+}
+
+// ROM is a read only memory device, writes are ignored.
+type ROM []byte
+
+// Read reads a byte from the ROM, reads outside of the ROM return 0.
+func (r ROM) Read(address uint16) uint8 {
+	if int(address) >= len(r) {
+		return 0
+	}
+	return r[address]
+}
+
+// Write ignores the write as the memory is read only.
+func (r ROM) Write(uint16, uint8) {}
+
+// DeviceFuncs adapts read and write functions to a device. A nil function
+// reads 0 or ignores the write.
+type DeviceFuncs struct {
+	ReadFunc  func(address uint16) uint8
+	WriteFunc func(address uint16, value uint8)
+}
+
+// Read calls the read function.
+func (d DeviceFuncs) Read(address uint16) uint8 {
+	if d.ReadFunc == nil {
+		return 0
+	}
+	return d.ReadFunc(address)
+}
+
+// Write calls the write function.
+func (d DeviceFuncs) Write(address uint16, value uint8) {
+	if d.WriteFunc != nil {
+		d.WriteFunc(address, value)
+	}
+}
@@ -0,0 +1,71 @@
+package bus
+
+import "errors"
+
+// UnmappedPortValue is the value that is read from ports that no device is
+// registered for, as the data bus lines float high on most systems.
+const UnmappedPortValue = 0xFF
+
+// IO dispatches port accesses to devices based on address masks, which
+// allows partial address decoding as done by most IO devices.
+// The devices are passed the full port address.
+type IO struct {
+	ports []port
+}
+
+type port struct {
+	mask   uint16
+	value  uint16
+	device Device
+}
+
+// NewIO returns a new IO dispatcher without registered devices.
+func NewIO() *IO {
+	return &IO{}
+}
+
+// Register registers the device for all port addresses that match the value
+// after applying the mask. If multiple registrations match, the first
+// registered device handles the access.
+func (io *IO) Register(mask, value uint16, device Device) error {
+	if device == nil {
+		return errors.New("device is nil")
+	}
+	if value&^mask != 0 {
+		return errors.New("value has bits set that are not in the mask")
+	}
+
+	io.ports = append(io.ports, port{
+		mask:   mask,
+		value:  value,
+		device: device,
+	})
+	return nil
+}
+
+// Read reads a byte from the device registered for the port address.
+func (io *IO) Read(address uint16) uint8 {
+	device := io.lookup(address)
+	if device == nil {
+		return UnmappedPortValue
+	}
+	return device.Read(address)
+}
+
+// Write writes a byte to the device registered for the port address.
+func (io *IO) Write(address uint16, value uint8) {
+	device := io.lookup(address)
+	if device == nil {
+		return
+	}
+	device.Write(address, value)
+}
+
+func (io *IO) lookup(address uint16) Device {
+	for _, p := range io.ports {
+		if address&p.mask == p.value {
+			return p.device
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package bus
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+type testTimer struct {
+	cycles uint64
+	value  uint8
+}
+
+func (t *testTimer) Read(uint16) uint8           { return t.value }
+func (t *testTimer) Write(_ uint16, value uint8) { t.value = value }
+func (t *testTimer) Tick(cycles uint64)          { t.cycles += cycles }
+func (t *testTimer) IRQ() bool                   { return t.cycles >= 100 }
+
+func TestIO(t *testing.T) {
+	io := NewIO()
+	timer := &testTimer{}
+	var writes []uint16
+	logger := DeviceFuncs{
+		WriteFunc: func(address uint16, _ uint8) {
+			writes = append(writes, address)
+		},
+	}
+
+	assert.NoError(t, io.Register(0x00FF, 0x0010, timer))
+	assert.NoError(t, io.Register(0x0001, 0x0000, logger))
+	assert.Error(t, io.Register(0x00F0, 0x0001, timer), "value has bits set that are not in the mask")
+
+	io.Write(0x1210, 5)
+	assert.Equal(t, 5, timer.value)
+	assert.Equal(t, 5, io.Read(0xFF10))
+
+	io.Write(0x3402, 1)
+	assert.Equal(t, []uint16{0x3402}, writes)
+	assert.Equal(t, UnmappedPortValue, io.Read(0x0003))
+}
+
+func TestPeripherals(t *testing.T) {
+	timer := &testTimer{}
+	peripherals := Peripherals{timer}
+
+	peripherals.Tick(60)
+	assert.False(t, peripherals.IRQ())
+	peripherals.Tick(40)
+	assert.True(t, peripherals.IRQ())
+}
@@ -0,0 +1,33 @@
+package bus
+
+// Peripheral is a device that runs in parallel to the CPU, like a timer or
+// sound chip, and that can request interrupts.
+type Peripheral interface {
+	Device
+
+	// Tick advances the state of the peripheral by the given amount of CPU cycles.
+	Tick(cycles uint64)
+	// IRQ returns whether the peripheral requests an interrupt.
+	IRQ() bool
+}
+
+// Peripherals is a list of peripherals that are ticked and polled for
+// interrupt requests together.
+type Peripherals []Peripheral
+
+// Tick advances the state of all peripherals by the given amount of CPU cycles.
+func (p Peripherals) Tick(cycles uint64) {
+	for _, peripheral := range p {
+		peripheral.Tick(cycles)
+	}
+}
+
+// IRQ returns whether any of the peripherals requests an interrupt.
+func (p Peripherals) IRQ() bool {
+	for _, peripheral := range p {
+		if peripheral.IRQ() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package chip8
+
+import "math"
+
+const (
+	// AudioPatternSize is the size of the XO-CHIP audio pattern buffer in bytes.
+	AudioPatternSize = 16
+	// DefaultPitch is the pitch that plays the audio pattern at 4000 bits per second.
+	DefaultPitch = 64
+
+	audioPatternBits = AudioPatternSize * 8
+)
+
+// DefaultAudioPattern is a square wave that is used as audio pattern until a
+// program loads its own pattern, it results in a 500 Hz tone at the default pitch.
+var DefaultAudioPattern = [AudioPatternSize]byte{
+	0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0,
+	0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0,
+}
+
+// PlaybackRate returns the rate in bits per second that the audio pattern
+// is played with at the current pitch.
+func (c *CPU) PlaybackRate() float64 {
+	return 4000 * math.Pow(2, (float64(c.Pitch)-DefaultPitch)/48)
+}
+
+// GenerateAudio fills the buffer with samples in the range of -1 to 1 for the
+// given sample rate. The audio pattern is played bit by bit, starting with the
+// most significant bit, and looped while the sound timer is active.
+// Silence is generated if the sound timer is not active.
+func (c *CPU) GenerateAudio(samples []float32, sampleRate int) {
+	if c.SoundTimer == 0 || sampleRate <= 0 {
+		clear(samples)
+		c.audioPhase = 0
+		return
+	}
+
+	step := c.PlaybackRate() / float64(sampleRate)
+	for i := range samples {
+		bit := int(c.audioPhase)
+		if c.AudioPattern[bit/8]&(0x80>>(bit%8)) != 0 {
+			samples[i] = 1
+		} else {
+			samples[i] = -1
+		}
+
+		c.audioPhase = math.Mod(c.audioPhase+step, audioPatternBits)
+	}
+}
@@ -0,0 +1,61 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestAudioInstructions(t *testing.T) {
+	c := New()
+	c.I = 0x300
+	for i := range AudioPatternSize {
+		c.Memory[0x300+i] = byte(i)
+	}
+	c.Memory[0x200] = 0xF0
+	c.Memory[0x201] = 0x02
+	c.Memory[0x202] = 0xF1
+	c.Memory[0x203] = 0x3A
+	c.V[1] = 112
+
+	assert.NoError(t, c.Step())
+	assert.NoError(t, c.Step())
+	assert.Equal(t, byte(15), c.AudioPattern[15])
+	assert.Equal(t, byte(112), c.Pitch)
+	assert.Equal(t, 8000.0, c.PlaybackRate())
+}
+
+func TestGenerateAudio(t *testing.T) {
+	c := New()
+	samples := make([]float32, 8)
+
+	c.GenerateAudio(samples, 4000)
+	assert.Equal(t, make([]float32, 8), samples)
+
+	c.SoundTimer = 1
+	c.GenerateAudio(samples, 4000)
+	assert.Equal(t, []float32{1, 1, 1, 1, -1, -1, -1, -1}, samples)
+
+	c.GenerateAudio(samples, 2000)
+	assert.Equal(t, []float32{1, 1, -1, -1, 1, 1, -1, -1}, samples)
+}
+
+func TestBeeper(t *testing.T) {
+	b := NewBeeper()
+	b.Frequency = 1000
+	b.Volume = 0.5
+
+	samples := make([]float32, 6)
+	b.Generate(samples, 4000, 0)
+	assert.Equal(t, make([]float32, 6), samples)
+
+	b.Generate(samples, 4000, 1)
+	assert.Equal(t, []float32{0.5, 0.5, -0.5, -0.5, 0.5, 0.5}, samples)
+
+	// sound timer of 1 lasts for 4000/60 samples
+	samples = make([]float32, 80)
+	b.Generate(samples, 4000, 1)
+	assert.Equal(t, float32(-0.5), samples[65])
+	assert.Equal(t, float32(0), samples[66])
+	assert.Equal(t, float32(0), samples[79])
+}
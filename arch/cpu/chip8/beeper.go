@@ -0,0 +1,58 @@
+package chip8
+
+import "math"
+
+const (
+	// TimerFrequency is the rate in Hz that the delay and sound timers count down with.
+	TimerFrequency = 60
+	// DefaultBeeperFrequency is the default tone frequency of the beeper in Hz.
+	DefaultBeeperFrequency = 440
+	// DefaultBeeperVolume is the default amplitude of the beeper samples.
+	DefaultBeeperVolume = 0.25
+)
+
+// Beeper generates the fixed square wave tone of classic Chip-8 systems from
+// the sound timer state, for frontends that do not play the XO-CHIP audio
+// pattern using GenerateAudio.
+type Beeper struct {
+	Frequency float64 // tone frequency in Hz
+	Volume    float32 // amplitude of the samples in the range of 0 to 1
+
+	phase float64 // position in the current wave period in the range of 0 to 1
+}
+
+// NewBeeper returns a new beeper using the default frequency and volume.
+func NewBeeper() *Beeper {
+	return &Beeper{
+		Frequency: DefaultBeeperFrequency,
+		Volume:    DefaultBeeperVolume,
+	}
+}
+
+// Generate fills the buffer with samples for the given sample rate. The tone
+// is generated for the remaining duration of the sound timer, which counts
+// down with TimerFrequency, the rest of the buffer is filled with silence.
+// The wave phase is kept between calls to avoid clicks at buffer boundaries.
+func (b *Beeper) Generate(samples []float32, sampleRate int, soundTimer byte) {
+	if sampleRate <= 0 {
+		clear(samples)
+		return
+	}
+
+	toneSamples := min(len(samples), int(soundTimer)*sampleRate/TimerFrequency)
+	step := b.Frequency / float64(sampleRate)
+
+	for i := range toneSamples {
+		if b.phase < 0.5 {
+			samples[i] = b.Volume
+		} else {
+			samples[i] = -b.Volume
+		}
+		b.phase = math.Mod(b.phase+step, 1)
+	}
+
+	clear(samples[toneSamples:])
+	if toneSamples < len(samples) {
+		b.phase = 0 // restart the wave with the next tone
+	}
+}
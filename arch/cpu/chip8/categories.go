@@ -0,0 +1,48 @@
+package chip8
+
+// BranchingInstructions contains all instructions that can change the
+// program flow, including the conditional skip instructions.
+var BranchingInstructions = map[string]struct{}{
+	Call.Name: {},
+	Jp.Name:   {},
+	Ret.Name:  {},
+	Se.Name:   {},
+	Skp.Name:  {},
+	Sknp.Name: {},
+	Sne.Name:  {},
+}
+
+// NotExecutingFollowingOpcodeInstructions contains all instructions that jump
+// to a different address and do not return to execute the following opcode.
+var NotExecutingFollowingOpcodeInstructions = map[string]struct{}{
+	Jp.Name:  {},
+	Ret.Name: {},
+}
+
+// MemoryReadOpcodes contains all opcodes that read from the memory address
+// in the I register. As the memory access of the ld instruction depends on
+// the addressing mode, opcodes are used instead of instruction names.
+var MemoryReadOpcodes = map[OpcodeInfo]struct{}{
+	OpcodeD000: {},
+	OpcodeF002: {},
+	OpcodeF065: {},
+}
+
+// MemoryWriteOpcodes contains all opcodes that write to the memory address
+// in the I register.
+var MemoryWriteOpcodes = map[OpcodeInfo]struct{}{
+	OpcodeF033: {},
+	OpcodeF055: {},
+}
+
+// ReadsMemory returns whether the opcode reads from memory.
+func (opcode Opcode) ReadsMemory() bool {
+	_, ok := MemoryReadOpcodes[opcode.Info]
+	return ok
+}
+
+// WritesMemory returns whether the opcode writes to memory.
+func (opcode Opcode) WritesMemory() bool {
+	_, ok := MemoryWriteOpcodes[opcode.Info]
+	return ok
+}
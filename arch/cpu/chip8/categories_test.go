@@ -0,0 +1,24 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestMemoryAccessOpcodes(t *testing.T) {
+	var reads, writes []uint16
+	for _, opcodes := range Opcodes {
+		for _, opcode := range opcodes {
+			if opcode.ReadsMemory() {
+				reads = append(reads, opcode.Info.Value)
+			}
+			if opcode.WritesMemory() {
+				writes = append(writes, opcode.Info.Value)
+			}
+		}
+	}
+
+	assert.ElementsMatch(t, []uint16{0xD000, 0xF002, 0xF065}, reads)
+	assert.ElementsMatch(t, []uint16{0xF033, 0xF055}, writes)
+}
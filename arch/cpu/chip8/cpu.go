@@ -19,25 +19,38 @@ type CPU struct {
 	DelayTimer byte // Delay timer
 	SoundTimer byte // Sound timer
 
+	AudioPattern [AudioPatternSize]byte // XO-CHIP 1-bit audio pattern buffer
+	Pitch        byte                   // XO-CHIP audio pattern playback pitch
+	audioPhase   float64                // position in the audio pattern in bits
+
 	Key [16]bool // Hexadecimal keypad state
 
-	Display      [displayWidth * displayHeight]byte // Monochrome display (64x32)
+	Display      [DisplayWidth * DisplayHeight]byte // Monochrome display (64x32)
 	RedrawScreen bool                               // Indicates if the screen needs to be redrawn
+	Palette      Palette                            // Colors used for rendering the display
+	dirtyRows    uint32                             // Bitmask of display rows changed since the last reset
 
 	rnd rand.Source // Random number generator
 }
 
 const (
-	displayHeight         = 32
-	displayWidth          = 64
+	// DisplayHeight is the height of the display in pixels.
+	DisplayHeight = 32
+	// DisplayWidth is the width of the display in pixels.
+	DisplayWidth = 64
+
 	initialProgramCounter = 0x200
 )
 
 // New creates a new CPU.
 func New() *CPU {
 	c := &CPU{
-		PC:  initialProgramCounter,
-		rnd: rand.New(rand.NewSource(time.Now().UnixNano())),
+		PC:           initialProgramCounter,
+		Palette:      DefaultPalette,
+		AudioPattern: DefaultAudioPattern,
+		Pitch:        DefaultPitch,
+		dirtyRows:    allRowsDirty,
+		rnd:          rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 
 	// Load fontset into memory
@@ -0,0 +1,110 @@
+package chip8
+
+import (
+	"image"
+	"image/color"
+	"math/bits"
+)
+
+// allRowsDirty marks all display rows as changed.
+const allRowsDirty = 1<<DisplayHeight - 1
+
+// Palette defines the colors that are used to render the display. The color
+// index is the value of a display pixel, bit 0 is set for pixels of the first
+// plane and bit 1 for pixels of the second XO-CHIP plane.
+type Palette [4]color.RGBA
+
+// Palette presets.
+var (
+	// DefaultPalette renders white pixels on a black background.
+	DefaultPalette = Palette{
+		{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+		{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		{R: 0xaa, G: 0xaa, B: 0xaa, A: 0xff},
+		{R: 0x55, G: 0x55, B: 0x55, A: 0xff},
+	}
+
+	// GreenPhosphorPalette mimics the look of a green phosphor monochrome monitor.
+	GreenPhosphorPalette = Palette{
+		{R: 0x0a, G: 0x1a, B: 0x0a, A: 0xff},
+		{R: 0x33, G: 0xff, B: 0x33, A: 0xff},
+		{R: 0x1f, G: 0xa0, B: 0x1f, A: 0xff},
+		{R: 0x99, G: 0xff, B: 0x99, A: 0xff},
+	}
+
+	// LCDPalette mimics the look of an early handheld LCD screen.
+	LCDPalette = Palette{
+		{R: 0x9b, G: 0xbc, B: 0x0f, A: 0xff},
+		{R: 0x0f, G: 0x38, B: 0x0f, A: 0xff},
+		{R: 0x30, G: 0x62, B: 0x30, A: 0xff},
+		{R: 0x8b, G: 0xac, B: 0x0f, A: 0xff},
+	}
+)
+
+// Color returns the color for the given display pixel value.
+func (p Palette) Color(pixel byte) color.RGBA {
+	return p[pixel&0b11]
+}
+
+// RenderDisplay renders the display to the image using the configured palette.
+// The image is expected to have a size of DisplayWidth x DisplayHeight,
+// pixels outside of the image bounds are skipped.
+func (c *CPU) RenderDisplay(img *image.RGBA) {
+	for y := range DisplayHeight {
+		c.renderRow(img, y)
+	}
+}
+
+// renderRow renders a display row to the image, pixels outside of the image
+// bounds are skipped.
+func (c *CPU) renderRow(img *image.RGBA, y int) {
+	bounds := img.Bounds()
+
+	for x := range DisplayWidth {
+		pt := image.Pt(bounds.Min.X+x, bounds.Min.Y+y)
+		if !pt.In(bounds) {
+			continue
+		}
+
+		pixel := c.Display[x+y*DisplayWidth]
+		img.SetRGBA(pt.X, pt.Y, c.Palette.Color(pixel))
+	}
+}
+
+// DirtyRows returns a bitmask of the display rows that changed since the
+// last reset, bit n is set for row n. All rows are dirty after creating the CPU.
+func (c *CPU) DirtyRows() uint32 {
+	return c.dirtyRows
+}
+
+// ResetDirtyRows marks all display rows as unchanged.
+func (c *CPU) ResetDirtyRows() {
+	c.dirtyRows = 0
+}
+
+// DirtyRect returns the display region that contains all changed rows, it
+// is empty if the display did not change since the last reset.
+func (c *CPU) DirtyRect() image.Rectangle {
+	if c.dirtyRows == 0 {
+		return image.Rectangle{}
+	}
+	top := bits.TrailingZeros32(c.dirtyRows)
+	bottom := 32 - bits.LeadingZeros32(c.dirtyRows)
+	return image.Rect(0, top, DisplayWidth, bottom)
+}
+
+// RenderDirtyRows renders only the changed display rows to the image, resets
+// the dirty rows and returns the display region that contains the rendered
+// rows. Frontends can use the region to upload only the changed part of the
+// image, for an idle display nothing is rendered and an empty region returned.
+func (c *CPU) RenderDirtyRows(img *image.RGBA) image.Rectangle {
+	rect := c.DirtyRect()
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		if c.dirtyRows&(1<<y) != 0 {
+			c.renderRow(img, y)
+		}
+	}
+
+	c.ResetDirtyRows()
+	return rect
+}
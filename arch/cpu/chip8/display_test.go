@@ -0,0 +1,48 @@
+package chip8
+
+import (
+	"image"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestRenderDisplay(t *testing.T) {
+	c := New()
+	c.Palette = GreenPhosphorPalette
+	c.Display[1] = 1
+	c.Display[DisplayWidth*DisplayHeight-1] = 1
+
+	img := image.NewRGBA(image.Rect(0, 0, DisplayWidth, DisplayHeight))
+	c.RenderDisplay(img)
+
+	assert.Equal(t, GreenPhosphorPalette[0], img.RGBAAt(0, 0))
+	assert.Equal(t, GreenPhosphorPalette[1], img.RGBAAt(1, 0))
+	assert.Equal(t, GreenPhosphorPalette[1], img.RGBAAt(DisplayWidth-1, DisplayHeight-1))
+}
+
+func TestRenderDirtyRows(t *testing.T) {
+	c := New()
+	img := image.NewRGBA(image.Rect(0, 0, DisplayWidth, DisplayHeight))
+	assert.Equal(t, image.Rect(0, 0, DisplayWidth, DisplayHeight), c.RenderDirtyRows(img))
+	assert.Equal(t, image.Rectangle{}, c.RenderDirtyRows(img))
+
+	// draw a 2 line sprite at row 5
+	c.Memory[0x300] = 0x80
+	c.Memory[0x301] = 0x00
+	c.I = 0x300
+	c.V[1] = 5
+	assert.NoError(t, drw(c, 0xD012))
+	assert.Equal(t, uint32(1<<5), c.DirtyRows())
+
+	assert.Equal(t, image.Rect(0, 5, DisplayWidth, 6), c.RenderDirtyRows(img))
+	assert.Equal(t, DefaultPalette[1], img.RGBAAt(0, 5))
+	assert.Equal(t, uint32(0), c.DirtyRows())
+
+	// clearing marks only the rows that had pixels set
+	assert.NoError(t, cls(c, 0))
+	assert.Equal(t, uint32(1<<5), c.DirtyRows())
+	c.ResetDirtyRows()
+	assert.NoError(t, cls(c, 0))
+	assert.Equal(t, image.Rectangle{}, c.DirtyRect())
+}
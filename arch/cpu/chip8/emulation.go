@@ -7,7 +7,10 @@ import (
 
 // cls clears the display.
 func cls(c *CPU, _ uint16) error {
-	for i := range c.Display {
+	for i, pixel := range c.Display {
+		if pixel != 0 {
+			c.dirtyRows |= 1 << (i / DisplayWidth)
+		}
 		c.Display[i] = 0
 	}
 	c.RedrawScreen = true
@@ -214,6 +217,9 @@ func ldF(c *CPU, param uint16) error {
 		c.I = uint16(c.V[reg]) * 0x5
 
 	case 0x33: // LD B, Vx
+		if err := c.checkMemoryAccess(3); err != nil {
+			return err
+		}
 		bcd := c.V[reg]
 		for i := 2; i >= 0; i-- {
 			c.Memory[c.I+uint16(i)] = bcd % 10
@@ -221,11 +227,17 @@ func ldF(c *CPU, param uint16) error {
 		}
 
 	case 0x55: // LD [I], Vx
+		if err := c.checkMemoryAccess(reg + 1); err != nil {
+			return err
+		}
 		for i := uint16(0); i <= reg; i++ {
 			c.Memory[c.I+i] = c.V[i]
 		}
 
 	case 0x65: // LD Vx, [I]
+		if err := c.checkMemoryAccess(reg + 1); err != nil {
+			return err
+		}
 		for i := uint16(0); i <= reg; i++ {
 			c.V[i] = c.Memory[c.I+i]
 		}
@@ -249,18 +261,24 @@ func and(c *CPU, param uint16) error {
 
 // drw displays n-byte sprite starting at memory location I at (Vx, Vy), set VF = collision.
 func drw(c *CPU, param uint16) error {
-	x := uint16(c.V[(param&0x0F00)>>8]) % displayWidth
-	y := uint16(c.V[(param&0x00F0)>>4]) % displayHeight
+	x := uint16(c.V[(param&0x0F00)>>8]) % DisplayWidth
+	y := uint16(c.V[(param&0x00F0)>>4]) % DisplayHeight
 	height := param & 0x000F
+	if err := c.checkMemoryAccess(height); err != nil {
+		return err
+	}
 
 	c.V[0xf] = 0
 
 	for yLine := range height {
 		sprite := c.Memory[c.I+yLine]
+		if sprite != 0 {
+			c.dirtyRows |= 1 << (y + yLine)
+		}
 
 		for xLine := range uint16(8) {
 			if (sprite & (0x80 >> xLine)) != 0 {
-				index := (x + xLine) + (y+yLine)*displayWidth
+				index := (x + xLine) + (y+yLine)*DisplayWidth
 				if c.Display[index] == 1 {
 					c.V[0xf] = 1
 				}
@@ -339,3 +357,30 @@ func subn(c *CPU, param uint16) error {
 	c.PC += 2
 	return nil
 }
+
+// audio loads the audio pattern buffer from memory location I.
+func audio(c *CPU, _ uint16) error {
+	if err := c.checkMemoryAccess(AudioPatternSize); err != nil {
+		return err
+	}
+	copy(c.AudioPattern[:], c.Memory[c.I:])
+	c.PC += 2
+	return nil
+}
+
+// pitch sets the audio pattern playback pitch to Vx.
+func pitch(c *CPU, param uint16) error {
+	reg := (param & 0x0F00) >> 8
+	c.Pitch = c.V[reg]
+	c.PC += 2
+	return nil
+}
+
+// checkMemoryAccess returns an error if the memory range of the given size
+// that starts at the address in the I register exceeds the memory.
+func (c *CPU) checkMemoryAccess(size uint16) error {
+	if int(c.I)+int(size) > len(c.Memory) {
+		return fmt.Errorf("memory access of %d bytes at I=0x%04X exceeds the memory", size, c.I)
+	}
+	return nil
+}
@@ -9,7 +9,7 @@ import (
 func TestCls(t *testing.T) {
 	c := New()
 	c.Display[0] = 1
-	c.Display[displayWidth+1] = 1
+	c.Display[DisplayWidth+1] = 1
 	assert.NoError(t, cls(c, 0))
 
 	for i := range 64 {
@@ -114,15 +114,15 @@ func TestDrw(t *testing.T) {
 	c.Memory[3] = 0b00001111
 	c.Display[0] = 1
 	c.Display[1] = 1
-	c.Display[displayWidth] = 1
-	c.Display[displayWidth+1] = 1
+	c.Display[DisplayWidth] = 1
+	c.Display[DisplayWidth+1] = 1
 	assert.NoError(t, drw(c, 0x0003))
 
 	assert.Equal(t, uint8(1), c.V[0xF])
 	assert.Equal(t, 0, c.Display[0])
 	assert.Equal(t, 0, c.Display[1])
-	assert.Equal(t, 1, c.Display[displayWidth])
-	assert.Equal(t, 1, c.Display[displayWidth+1])
+	assert.Equal(t, 1, c.Display[DisplayWidth])
+	assert.Equal(t, 1, c.Display[DisplayWidth+1])
 }
 
 func TestRnd(t *testing.T) {
@@ -188,3 +188,15 @@ func TestSubn(t *testing.T) {
 	assert.NoError(t, subn(c, 0x0010))
 	assert.Equal(t, uint8(0x22), c.V[0])
 }
+
+func TestMemoryAccessOutOfBounds(t *testing.T) {
+	c := New()
+	c.I = 0x1000
+	assert.Error(t, audio(c, 0xF002), "memory access of 16 bytes at I=0x1000 exceeds the memory")
+	assert.Error(t, ldF(c, 0xF065), "memory access of 1 bytes at I=0x1000 exceeds the memory")
+	assert.Error(t, drw(c, 0xD001), "memory access of 1 bytes at I=0x1000 exceeds the memory")
+
+	c.I = 0xFFE
+	assert.Error(t, ldF(c, 0xF033), "memory access of 3 bytes at I=0x0FFE exceeds the memory")
+	assert.Error(t, ldF(c, 0xF255), "memory access of 3 bytes at I=0x0FFE exceeds the memory")
+}
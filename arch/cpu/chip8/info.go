@@ -0,0 +1,54 @@
+package chip8
+
+import "github.com/retroenv/retrogolib/arch/cpu"
+
+var _ cpu.Instruction = &Instruction{}
+
+// addressingOperands maps the addressing modes to their operand kinds.
+var addressingOperands = map[Mode]cpu.OperandKind{
+	AbsoluteAddressing:               cpu.AddressOperand,
+	V0AbsoluteAddressing:             cpu.RegisterOperand | cpu.AddressOperand,
+	RegisterAddressing:               cpu.RegisterOperand,
+	RegisterValueAddressing:          cpu.RegisterOperand | cpu.ImmediateOperand,
+	RegisterRegisterAddressing:       cpu.RegisterOperand,
+	RegisterRegisterNibbleAddressing: cpu.RegisterOperand | cpu.ImmediateOperand,
+	RegisterDTAddressing:             cpu.RegisterOperand,
+	RegisterKAddressing:              cpu.RegisterOperand,
+	RegisterIndirectIAddressing:      cpu.RegisterOperand | cpu.IndirectOperand,
+	DTRegisterAddressing:             cpu.RegisterOperand,
+	STRegisterAddressing:             cpu.RegisterOperand,
+	FRegisterAddressing:              cpu.RegisterOperand,
+	BRegisterAddressing:              cpu.RegisterOperand,
+	IAbsoluteAddressing:              cpu.RegisterOperand | cpu.AddressOperand,
+	IRegisterAddressing:              cpu.RegisterOperand,
+	IIndirectRegisterAddressing:      cpu.RegisterOperand | cpu.IndirectOperand,
+}
+
+// Info returns the architecture independent metadata of the instruction.
+// Chip-8 has no cycle timing, so the cycle range is not set.
+func (ins *Instruction) Info() cpu.InstructionInfo {
+	_, branching := BranchingInstructions[ins.Name]
+	info := cpu.InstructionInfo{
+		Name:      ins.Name,
+		Branching: branching,
+	}
+
+	for addressing := range ins.Addressing {
+		info.Operands |= addressingOperands[addressing]
+	}
+
+	for _, opcodes := range Opcodes {
+		for _, opcode := range opcodes {
+			if opcode.Instruction != ins {
+				continue
+			}
+			if opcode.ReadsMemory() {
+				info.Memory |= cpu.MemoryRead
+			}
+			if opcode.WritesMemory() {
+				info.Memory |= cpu.MemoryWrite
+			}
+		}
+	}
+	return info
+}
@@ -0,0 +1,24 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/arch/cpu"
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestInstructionInfo(t *testing.T) {
+	info := Ld.Info()
+	assert.Equal(t, "ld", info.Name)
+	assert.Equal(t, cpu.MemoryReadWrite, info.Memory)
+	assert.True(t, info.Operands.Has(cpu.RegisterOperand|cpu.ImmediateOperand|cpu.IndirectOperand))
+	assert.False(t, info.Branching)
+	assert.Equal(t, 0, info.MaxCycles)
+
+	info = Jp.Info()
+	assert.True(t, info.Branching)
+	assert.Equal(t, cpu.NoMemoryAccess, info.Memory)
+
+	info = Drw.Info()
+	assert.Equal(t, cpu.MemoryRead, info.Memory)
+}
@@ -32,6 +32,15 @@ var And = &Instruction{
 	},
 }
 
+// Audio - XO-CHIP: Load the 16 byte audio pattern buffer from memory location I.
+var Audio = &Instruction{
+	Name:      "audio",
+	Emulation: audio,
+	Addressing: map[Mode]OpcodeInfo{
+		ImpliedAddressing: OpcodeF002,
+	},
+}
+
 // Call - Call subroutine.
 var Call = &Instruction{
 	Name:      "call",
@@ -97,6 +106,15 @@ var Or = &Instruction{
 	},
 }
 
+// Pitch - XO-CHIP: Set the audio pattern playback pitch to Vx.
+var Pitch = &Instruction{
+	Name:      "pitch",
+	Emulation: pitch,
+	Addressing: map[Mode]OpcodeInfo{
+		RegisterAddressing: OpcodeF03A,
+	},
+}
+
 // Ret - Return from a subroutine.
 var Ret = &Instruction{
 	Name:      "ret",
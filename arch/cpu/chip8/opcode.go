@@ -69,6 +69,7 @@ var Opcodes = [16][]Opcode{
 		{Info: OpcodeE0A1, Instruction: Sknp},
 	},
 	0xF: {
+		{Info: OpcodeF002, Instruction: Audio},
 		{Info: OpcodeF007, Instruction: Ld},
 		{Info: OpcodeF00A, Instruction: Ld},
 		{Info: OpcodeF015, Instruction: Ld},
@@ -76,6 +77,7 @@ var Opcodes = [16][]Opcode{
 		{Info: OpcodeF01E, Instruction: Add},
 		{Info: OpcodeF029, Instruction: Ld},
 		{Info: OpcodeF033, Instruction: Ld},
+		{Info: OpcodeF03A, Instruction: Pitch},
 		{Info: OpcodeF055, Instruction: Ld},
 		{Info: OpcodeF065, Instruction: Ld},
 	},
@@ -107,6 +109,7 @@ var (
 	OpcodeD000 = OpcodeInfo{Value: 0xD000, Mask: 0xF000}
 	OpcodeE09E = OpcodeInfo{Value: 0xE09E, Mask: 0xF0FF}
 	OpcodeE0A1 = OpcodeInfo{Value: 0xE0A1, Mask: 0xF0FF}
+	OpcodeF002 = OpcodeInfo{Value: 0xF002, Mask: 0xFFFF}
 	OpcodeF007 = OpcodeInfo{Value: 0xF007, Mask: 0xF0FF}
 	OpcodeF00A = OpcodeInfo{Value: 0xF00A, Mask: 0xF0FF}
 	OpcodeF015 = OpcodeInfo{Value: 0xF015, Mask: 0xF0FF}
@@ -114,6 +117,7 @@ var (
 	OpcodeF01E = OpcodeInfo{Value: 0xF01E, Mask: 0xF0FF}
 	OpcodeF029 = OpcodeInfo{Value: 0xF029, Mask: 0xF0FF}
 	OpcodeF033 = OpcodeInfo{Value: 0xF033, Mask: 0xF0FF}
+	OpcodeF03A = OpcodeInfo{Value: 0xF03A, Mask: 0xF0FF}
 	OpcodeF055 = OpcodeInfo{Value: 0xF055, Mask: 0xF0FF}
 	OpcodeF065 = OpcodeInfo{Value: 0xF065, Mask: 0xF0FF}
 )
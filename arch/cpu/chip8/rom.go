@@ -0,0 +1,46 @@
+package chip8
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MaxROMSize is the maximum size of a ROM that fits into the program memory.
+const MaxROMSize = len(CPU{}.Memory) - initialProgramCounter
+
+// ROMInfo contains the metadata of a loaded ROM.
+// The CPU does not implement configurable quirks, so no quirk presets are
+// applied for known ROMs. The SHA1 hash allows callers to look up ROM
+// specific settings in their own database.
+type ROMInfo struct {
+	Size int
+	SHA1 string // hex encoded SHA-1 hash of the ROM
+}
+
+// LoadROM reads a .ch8 ROM and copies it into the program memory starting
+// at 0x200. Program memory that is not covered by the ROM is cleared.
+func (c *CPU) LoadROM(reader io.Reader) (ROMInfo, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, int64(MaxROMSize)+1))
+	if err != nil {
+		return ROMInfo{}, fmt.Errorf("reading ROM: %w", err)
+	}
+	if len(data) == 0 {
+		return ROMInfo{}, errors.New("ROM is empty")
+	}
+	if len(data) > MaxROMSize {
+		return ROMInfo{}, fmt.Errorf("ROM size exceeds the available program memory of %d bytes", MaxROMSize)
+	}
+
+	program := c.Memory[initialProgramCounter:]
+	clear(program)
+	copy(program, data)
+
+	hash := sha1.Sum(data)
+	return ROMInfo{
+		Size: len(data),
+		SHA1: hex.EncodeToString(hash[:]),
+	}, nil
+}
@@ -0,0 +1,30 @@
+package chip8
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestLoadROM(t *testing.T) {
+	c := New()
+	c.Memory[0x300] = 0xff
+
+	info, err := c.LoadROM(bytes.NewReader([]byte{0x00, 0xE0, 0x12}))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, info.Size)
+	assert.Equal(t, "a23bdf542ed1f6b6c1eea83c5d74ed79c56d4e19", info.SHA1)
+	assert.Equal(t, []byte{0x00, 0xE0, 0x12, 0x00}, c.Memory[0x200:0x204])
+	assert.Equal(t, 0, c.Memory[0x300])
+	assert.Equal(t, fontSet[0], c.Memory[0])
+
+	_, err = c.LoadROM(bytes.NewReader(nil))
+	assert.Error(t, err, "ROM is empty")
+
+	_, err = c.LoadROM(bytes.NewReader(make([]byte, MaxROMSize+1)))
+	assert.Error(t, err, "ROM size exceeds the available program memory of 3584 bytes")
+
+	_, err = c.LoadROM(bytes.NewReader(make([]byte, MaxROMSize)))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,145 @@
+package cpu
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math/bits"
+	"strconv"
+	"sync"
+)
+
+// Coverage collects the execution coverage of any CPU core, it counts the
+// hits of every opcode and keeps a bitmap of the executed addresses. The
+// CPU packages provide options that record into a coverage.
+// All methods are safe for concurrent use.
+type Coverage struct {
+	mu sync.Mutex
+
+	addressSpace uint64
+	executed     []uint64 // bitmap of executed addresses
+	opcodes      map[uint64]uint64
+}
+
+// AddressRange is an inclusive range of addresses.
+type AddressRange struct {
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+}
+
+// CoverageReport is the exported form of a coverage.
+type CoverageReport struct {
+	AddressSpace      uint64            `json:"address_space"`
+	ExecutedAddresses uint64            `json:"executed_addresses"`
+	ExecutedRanges    []AddressRange    `json:"executed_ranges"`
+	OpcodeHits        map[string]uint64 `json:"opcode_hits"` // keys are hex formatted opcodes
+}
+
+// NewCoverage returns a new coverage for an address space of the given size,
+// for example 0x10000 for a CPU with a 16 bit address bus.
+func NewCoverage(addressSpace uint64) *Coverage {
+	return &Coverage{
+		addressSpace: addressSpace,
+		executed:     make([]uint64, (addressSpace+63)/64),
+		opcodes:      map[uint64]uint64{},
+	}
+}
+
+// Record records the execution of the opcode at the address. Addresses
+// outside of the address space only count the opcode hit.
+func (c *Coverage) Record(address, opcode uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.opcodes[opcode]++
+	if address < c.addressSpace {
+		c.executed[address/64] |= 1 << (address % 64)
+	}
+}
+
+// Executed returns whether the address was executed.
+func (c *Coverage) Executed(address uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if address >= c.addressSpace {
+		return false
+	}
+	return c.executed[address/64]&(1<<(address%64)) != 0
+}
+
+// ExecutedAddresses returns the number of executed addresses.
+func (c *Coverage) ExecutedAddresses() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.executedAddresses()
+}
+
+// executedAddresses returns the number of executed addresses, the caller
+// has to hold the lock.
+func (c *Coverage) executedAddresses() uint64 {
+	var count int
+	for _, word := range c.executed {
+		count += bits.OnesCount64(word)
+	}
+	return uint64(count)
+}
+
+// OpcodeHits returns a copy of the hit counts per opcode.
+func (c *Coverage) OpcodeHits() map[uint64]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.opcodes)
+}
+
+// Reset removes all recorded coverage.
+func (c *Coverage) Reset() {
+	c.mu.Lock()
+	clear(c.executed)
+	clear(c.opcodes)
+	c.mu.Unlock()
+}
+
+// Report returns the exported form of the coverage, the executed addresses
+// are merged into ranges of consecutive addresses.
+func (c *Coverage) Report() CoverageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := CoverageReport{
+		AddressSpace:      c.addressSpace,
+		ExecutedAddresses: c.executedAddresses(),
+		OpcodeHits:        map[string]uint64{},
+	}
+
+	for opcode, hits := range c.opcodes {
+		report.OpcodeHits["0x"+strconv.FormatUint(opcode, 16)] = hits
+	}
+
+	inRange := false
+	for address := range c.addressSpace {
+		executed := c.executed[address/64]&(1<<(address%64)) != 0
+		switch {
+		case executed && !inRange:
+			report.ExecutedRanges = append(report.ExecutedRanges, AddressRange{Start: address, End: address})
+			inRange = true
+		case executed:
+			report.ExecutedRanges[len(report.ExecutedRanges)-1].End = address
+		default:
+			inRange = false
+		}
+	}
+	return report
+}
+
+// WriteJSON writes the coverage report as indented JSON to the writer.
+func (c *Coverage) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(c.Report()); err != nil {
+		return fmt.Errorf("encoding coverage report: %w", err)
+	}
+	return nil
+}
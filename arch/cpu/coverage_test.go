@@ -0,0 +1,42 @@
+package cpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestCoverage(t *testing.T) {
+	c := NewCoverage(0x10000)
+	c.Record(0x8000, 0xa9)
+	c.Record(0x8002, 0xe8)
+	c.Record(0x8003, 0xa9)
+	c.Record(0x8010, 0xa9)
+	c.Record(0x10000, 0xea) // outside of the address space
+
+	assert.True(t, c.Executed(0x8000))
+	assert.False(t, c.Executed(0x8001))
+	assert.False(t, c.Executed(0x10000))
+	assert.Equal(t, 4, c.ExecutedAddresses())
+	assert.Equal(t, map[uint64]uint64{0xa9: 3, 0xe8: 1, 0xea: 1}, c.OpcodeHits())
+
+	report := c.Report()
+	assert.Equal(t, []AddressRange{
+		{Start: 0x8000, End: 0x8000},
+		{Start: 0x8002, End: 0x8003},
+		{Start: 0x8010, End: 0x8010},
+	}, report.ExecutedRanges)
+	assert.Equal(t, 3, report.OpcodeHits["0xa9"])
+
+	var buf bytes.Buffer
+	assert.NoError(t, c.WriteJSON(&buf))
+	var decoded CoverageReport
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report, decoded)
+
+	c.Reset()
+	assert.Equal(t, 0, c.ExecutedAddresses())
+	assert.Len(t, c.OpcodeHits(), 0)
+}
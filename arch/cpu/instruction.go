@@ -0,0 +1,65 @@
+// Package cpu contains architecture independent CPU instruction metadata,
+// which allows tooling like disassembler frontends or coverage analyzers to
+// work with all supported CPU architectures.
+package cpu
+
+// OperandKind defines the kinds of operands of an instruction as bit flags.
+type OperandKind int
+
+// operand kinds.
+const (
+	NoOperand        OperandKind = 0
+	ImmediateOperand OperandKind = 1 << iota // constant value
+	RegisterOperand                          // CPU register, also used for indexing
+	AddressOperand                           // absolute memory address
+	IndirectOperand                          // memory address that is read from a pointer
+	RelativeOperand                          // address relative to the program counter
+)
+
+// Has returns whether all of the passed kinds are set.
+func (k OperandKind) Has(kinds OperandKind) bool {
+	return k&kinds == kinds
+}
+
+// MemoryAccess defines how an instruction accesses memory operands.
+type MemoryAccess int
+
+// memory access modes.
+const (
+	NoMemoryAccess  MemoryAccess = 0
+	MemoryRead      MemoryAccess = 1
+	MemoryWrite     MemoryAccess = 2
+	MemoryReadWrite              = MemoryRead | MemoryWrite
+)
+
+// Reads returns whether memory is read.
+func (m MemoryAccess) Reads() bool {
+	return m&MemoryRead != 0
+}
+
+// Writes returns whether memory is written.
+func (m MemoryAccess) Writes() bool {
+	return m&MemoryWrite != 0
+}
+
+// InstructionInfo contains the architecture independent metadata of an instruction.
+type InstructionInfo struct {
+	Name       string // lowercased instruction name
+	Unofficial bool   // instruction is not part of the original CPU spec
+
+	Operands  OperandKind  // operand kinds of all addressing modes of the instruction
+	Memory    MemoryAccess // access to memory operands, stack accesses are not included
+	Branching bool         // instruction can change the program flow
+
+	// MinCycles and MaxCycles contain the range of CPU cycles that the
+	// instruction takes over all addressing modes. They are 0 for
+	// architectures without cycle timing.
+	MinCycles int
+	MaxCycles int
+}
+
+// Instruction is implemented by the instruction types of all CPU packages.
+type Instruction interface {
+	// Info returns the architecture independent metadata of the instruction.
+	Info() InstructionInfo
+}
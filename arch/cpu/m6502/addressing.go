@@ -32,6 +32,16 @@ const (
 	ReadWriteAccess AccessMode = 3
 )
 
+// Reads returns whether the access mode includes reading.
+func (a AccessMode) Reads() bool {
+	return a&ReadAccess != 0
+}
+
+// Writes returns whether the access mode includes writing.
+func (a AccessMode) Writes() bool {
+	return a&WriteAccess != 0
+}
+
 // AccessModeConstant is used to specify for every memory address what access mode applies to it.
 // A memory address like 0x4017 has a different meaning depending on the type of access.
 type AccessModeConstant struct {
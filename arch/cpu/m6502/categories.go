@@ -67,3 +67,45 @@ var MemoryReadWriteInstructions = map[string]struct{}{
 	Slo.Name: {},
 	Sre.Name: {},
 }
+
+// instructionMemoryAccess contains the type of memory access of every
+// instruction that accesses memory through its operand. Unlike the memory
+// instruction categories, arithmetic and logic instructions like adc that
+// only read their operand are classified as read access. Only the indirect
+// addressing of jmp reads memory, the pointer to the jump target. The
+// unofficial nop opcodes with an address operand read it without using it.
+var instructionMemoryAccess = map[string]AccessMode{
+	Adc.Name:           ReadAccess,
+	And.Name:           ReadAccess,
+	Bit.Name:           ReadAccess,
+	Cmp.Name:           ReadAccess,
+	Cpx.Name:           ReadAccess,
+	Cpy.Name:           ReadAccess,
+	Eor.Name:           ReadAccess,
+	Jmp.Name:           ReadAccess, // indirect addressing only
+	Lax.Name:           ReadAccess,
+	Lda.Name:           ReadAccess,
+	Ldx.Name:           ReadAccess,
+	Ldy.Name:           ReadAccess,
+	NopUnofficial.Name: ReadAccess,
+	Ora.Name:           ReadAccess,
+	Sbc.Name:           ReadAccess,
+
+	Sax.Name: WriteAccess,
+	Sta.Name: WriteAccess,
+	Stx.Name: WriteAccess,
+	Sty.Name: WriteAccess,
+
+	Asl.Name: ReadWriteAccess,
+	Dcp.Name: ReadWriteAccess,
+	Dec.Name: ReadWriteAccess,
+	Inc.Name: ReadWriteAccess,
+	Isc.Name: ReadWriteAccess,
+	Lsr.Name: ReadWriteAccess,
+	Rla.Name: ReadWriteAccess,
+	Rol.Name: ReadWriteAccess,
+	Ror.Name: ReadWriteAccess,
+	Rra.Name: ReadWriteAccess,
+	Slo.Name: ReadWriteAccess,
+	Sre.Name: ReadWriteAccess,
+}
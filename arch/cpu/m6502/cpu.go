@@ -27,7 +27,10 @@ type CPU struct {
 	Flags Flags
 
 	cycles      uint64
-	stallCycles uint16 // TODO stall cycles, use a Step() function
+	stallCycles uint64 // pending cycles that the CPU is stalled for, like DMA
+	rdyLow      bool   // RDY input line is pulled low and halts the CPU
+	branchTaken bool   // set if the currently executed branch instruction jumped
+	opcode      uint8  // opcode byte of the currently executed instruction
 
 	triggerIrq bool
 	triggerNmi bool
@@ -41,7 +44,8 @@ type CPU struct {
 	opts      Options
 	TraceStep TraceStep // trace step info, set if tracing is enabled
 
-	memory *Memory
+	memory  *Memory
+	watcher *writeWatcher // set if write notifications are enabled
 }
 
 const (
@@ -50,22 +54,39 @@ const (
 	InitialStack  = 0xFD
 )
 
-// New creates a new CPU.
+// New creates a new CPU. If the address bus width is limited or write
+// notifications are enabled by an option, the CPU accesses the memory through
+// a new Memory that wraps the passed one, it is returned by the Memory function.
 func New(memory *Memory, options ...Option) *CPU {
 	opts := NewOptions(options...)
+
+	var watcher *writeWatcher
+	if opts.writeHandler != nil {
+		watcher = &writeWatcher{
+			BasicMemory: memory.BasicMemory,
+			ranges:      opts.writeRanges,
+			handler:     opts.writeHandler,
+			last:        map[uint16]uint8{},
+		}
+		memory = NewMemory(watcher)
+	}
+	if opts.addressMask != 0 {
+		memory = NewMemory(maskedMemory{
+			BasicMemory: memory.BasicMemory,
+			mask:        opts.addressMask,
+		})
+	}
 	c := &CPU{
-		SP:     InitialStack,
-		cycles: initialCycles,
-		opts:   opts,
-		memory: memory,
+		opts:    opts,
+		memory:  memory,
+		watcher: watcher,
 	}
+	c.powerOn()
 
 	// read interrupt handler addresses
 	c.nmiAddress = memory.ReadWordBug(NMIAddress)
 	c.PC = memory.ReadWordBug(ResetAddress)
 	c.irqAddress = memory.ReadWordBug(IrqAddress)
-
-	c.setFlags(initialFlags)
 	return c
 }
 
@@ -74,9 +95,24 @@ func (c *CPU) Cycles() uint64 {
 	return c.cycles
 }
 
-// StallCycles stalls the CPU for the given amount of cycles. This is used for DMA transfer in the PPU.
+// StallCycles stalls the CPU for the given amount of cycles, which models
+// cycles that are taken by DMA transfers like the OAM DMA of the PPU.
+// Multiple stalls add up, the cycles are consumed by the next Step before
+// executing the instruction.
 func (c *CPU) StallCycles(cycles uint16) {
-	c.stallCycles = cycles
+	c.stallCycles += uint64(cycles)
+}
+
+// SetRDY sets the level of the RDY input line. While the line is low the CPU
+// is halted and every Step consumes a single cycle without executing an
+// instruction, which allows platform code to model cycle stealing devices.
+func (c *CPU) SetRDY(ready bool) {
+	c.rdyLow = !ready
+}
+
+// Halted returns whether the CPU is halted by the RDY line.
+func (c *CPU) Halted() bool {
+	return c.rdyLow
 }
 
 // State returns the current state of the CPU.
@@ -122,8 +158,13 @@ func (c *CPU) branch(branchTo bool, param any) {
 	}
 
 	addr := param.(Absolute)
+	c.takeBranch(uint16(addr))
+}
 
-	c.PC = uint16(addr)
+// takeBranch jumps to the branch target address.
+func (c *CPU) takeBranch(address uint16) {
+	c.PC = address
+	c.branchTaken = true
 	c.cycles++
 }
 
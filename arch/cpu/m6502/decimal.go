@@ -0,0 +1,63 @@
+package m6502
+
+// decimalMode returns whether ADC and SBC operate on binary coded decimals.
+func (c *CPU) decimalMode() bool {
+	return c.Flags.D == 1 && !c.opts.noDecimalMode
+}
+
+// adcDecimal adds the value and carry to the accumulator in decimal mode.
+// The flags are set like on the NMOS 6502: Z is based on the binary result,
+// N and V are based on the result before the adjustment of the high nibble.
+// The results for invalid BCD values match the NMOS 6502 as well.
+func (c *CPU) adcDecimal(value uint8) {
+	a := c.A
+	carry := int(c.Flags.C)
+
+	low := int(a&0x0f) + int(value&0x0f) + carry
+	if low >= 0x0a {
+		low = ((low + 0x06) & 0x0f) + 0x10
+	}
+
+	sum := int(a&0xf0) + int(value&0xf0) + low
+	signed := int(int8(a&0xf0)) + int(int8(value&0xf0)) + low
+
+	c.setZ(uint8(int(a) + int(value) + carry))
+	c.setN(uint8(sum))
+	c.setV(signed < -128 || signed > 127)
+
+	if sum >= 0xa0 {
+		sum += 0x60
+	}
+	if sum >= 0x100 {
+		c.Flags.C = 1
+	} else {
+		c.Flags.C = 0
+	}
+	c.A = uint8(sum)
+}
+
+// sbcDecimal subtracts the value and borrow from the accumulator in decimal
+// mode. The flags are set like on the NMOS 6502, based on the binary result.
+func (c *CPU) sbcDecimal(value uint8) {
+	a := c.A
+	borrow := 1 - int(c.Flags.C)
+
+	binary := int(a) - int(value) - borrow
+	c.setZN(uint8(binary))
+	c.setV((a^value)&0x80 != 0 && (a^uint8(binary))&0x80 != 0)
+	if binary >= 0 {
+		c.Flags.C = 1
+	} else {
+		c.Flags.C = 0
+	}
+
+	low := int(a&0x0f) - int(value&0x0f) - borrow
+	if low < 0 {
+		low = ((low - 0x06) & 0x0f) - 0x10
+	}
+	result := int(a&0xf0) - int(value&0xf0) + low
+	if result < 0 {
+		result -= 0x60
+	}
+	c.A = uint8(result)
+}
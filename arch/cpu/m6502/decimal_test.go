@@ -0,0 +1,63 @@
+package m6502
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestDecimalMode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		sbc      bool
+		a, value uint8
+		carry    uint8
+		result   uint8
+		c, z, n  uint8
+		overflow uint8
+	}{
+		{name: "adc 12+34", a: 0x12, value: 0x34, result: 0x46},
+		{name: "adc 15+26", a: 0x15, value: 0x26, result: 0x41},
+		{name: "adc 58+46+1", a: 0x58, value: 0x46, carry: 1, result: 0x05, c: 1, n: 1, overflow: 1},
+		{name: "adc 81+92", a: 0x81, value: 0x92, result: 0x73, c: 1, overflow: 1},
+		{name: "adc 99+01", a: 0x99, value: 0x01, result: 0x00, c: 1, n: 1},
+		{name: "sbc 46-12", sbc: true, a: 0x46, value: 0x12, carry: 1, result: 0x34, c: 1},
+		{name: "sbc 40-13", sbc: true, a: 0x40, value: 0x13, carry: 1, result: 0x27, c: 1},
+		{name: "sbc 32-02-1", sbc: true, a: 0x32, value: 0x02, result: 0x29, c: 1},
+		{name: "sbc 12-21", sbc: true, a: 0x12, value: 0x21, carry: 1, result: 0x91, n: 1},
+		{name: "sbc 21-21", sbc: true, a: 0x21, value: 0x21, carry: 1, result: 0x00, c: 1, z: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			cpu := cpuTestSetup()
+			cpu.Flags.D = 1
+			cpu.Flags.C = test.carry
+			cpu.A = test.a
+
+			if test.sbc {
+				assert.NoError(t, sbc(cpu, int(test.value)))
+			} else {
+				assert.NoError(t, adc(cpu, int(test.value)))
+			}
+
+			assert.Equal(t, test.result, cpu.A)
+			assert.Equal(t, test.c, cpu.Flags.C)
+			assert.Equal(t, test.z, cpu.Flags.Z)
+			assert.Equal(t, test.n, cpu.Flags.N)
+			assert.Equal(t, test.overflow, cpu.Flags.V)
+		})
+	}
+}
+
+func TestWithoutDecimalMode(t *testing.T) {
+	t.Parallel()
+	cpu := New(NewMemory(&testMemory{}), WithoutDecimalMode())
+	cpu.Flags.D = 1
+	cpu.A = 0x09
+
+	assert.NoError(t, adc(cpu, 0x01))
+	assert.Equal(t, 0x0a, cpu.A)
+}
@@ -0,0 +1,211 @@
+package m6502
+
+// executor is the pre-bound dispatch entry of an opcode byte. All info that
+// Step needs is resolved once at package initialization, which avoids map
+// lookups and name comparisons while executing instructions.
+type executor struct {
+	opcode     Opcode
+	readParams paramReaderFunc
+	size       uint16 // size of the opcode and its operands in bytes
+
+	jump            bool // jmp instruction that can loop to itself
+	branching       bool // instruction is part of BranchingInstructions
+	branchPageCycle bool // page crossing of the branch target costs a cycle
+
+	// execute runs the instruction with inlined operand decoding and returns
+	// whether indexing crossed a page. It is nil for instructions without
+	// parameters and for instructions that are only supported by the generic
+	// parameter based execution path.
+	execute func(c *CPU) bool
+}
+
+// dispatchTable maps every opcode byte to its executor.
+var dispatchTable = newDispatchTable()
+
+// addressResolver returns the effective address of an operand and whether
+// indexing crossed a page boundary. For immediate addressing the address of
+// the operand byte is returned.
+type addressResolver func(c *CPU) (uint16, bool)
+
+// addressResolvers contains the resolvers for all addressing modes that
+// access a memory operand.
+var addressResolvers = map[AddressingMode]addressResolver{
+	ImmediateAddressing: resolveImmediate,
+	ZeroPageAddressing:  resolveZeroPage,
+	ZeroPageXAddressing: resolveZeroPageX,
+	ZeroPageYAddressing: resolveZeroPageY,
+	AbsoluteAddressing:  resolveAbsolute,
+	AbsoluteXAddressing: resolveAbsoluteX,
+	AbsoluteYAddressing: resolveAbsoluteY,
+	IndirectXAddressing: resolveIndirectX,
+	IndirectYAddressing: resolveIndirectY,
+}
+
+// valueReadInstructions maps instructions that only read their operand to
+// the function that processes the operand value.
+var valueReadInstructions = map[*Instruction]func(c *CPU, value uint8){
+	Adc: adcValue,
+	And: andValue,
+	Bit: bitValue,
+	Cmp: cmpValue,
+	Cpx: cpxValue,
+	Cpy: cpyValue,
+	Eor: eorValue,
+	Lda: ldaValue,
+	Ldx: ldxValue,
+	Ldy: ldyValue,
+	Ora: oraValue,
+	Sbc: sbcValue,
+}
+
+// valueWriteInstructions maps instructions that only write their operand to
+// the function that returns the value to write.
+var valueWriteInstructions = map[*Instruction]func(c *CPU) uint8{
+	Sta: func(c *CPU) uint8 { return c.A },
+	Stx: func(c *CPU) uint8 { return c.X },
+	Sty: func(c *CPU) uint8 { return c.Y },
+}
+
+// branchConditions maps the branch instructions to their branch condition.
+var branchConditions = map[*Instruction]func(c *CPU) bool{
+	Bcc: func(c *CPU) bool { return c.Flags.C == 0 },
+	Bcs: func(c *CPU) bool { return c.Flags.C != 0 },
+	Beq: func(c *CPU) bool { return c.Flags.Z != 0 },
+	Bmi: func(c *CPU) bool { return c.Flags.N != 0 },
+	Bne: func(c *CPU) bool { return c.Flags.Z == 0 },
+	Bpl: func(c *CPU) bool { return c.Flags.N == 0 },
+	Bvc: func(c *CPU) bool { return c.Flags.V == 0 },
+	Bvs: func(c *CPU) bool { return c.Flags.V != 0 },
+}
+
+// addressingSize contains the size of an opcode including its operands for
+// every addressing mode.
+var addressingSize = map[AddressingMode]uint16{
+	ImpliedAddressing:     1,
+	AccumulatorAddressing: 1,
+	ImmediateAddressing:   2,
+	ZeroPageAddressing:    2,
+	ZeroPageXAddressing:   2,
+	ZeroPageYAddressing:   2,
+	RelativeAddressing:    2,
+	IndirectXAddressing:   2,
+	IndirectYAddressing:   2,
+	AbsoluteAddressing:    3,
+	AbsoluteXAddressing:   3,
+	AbsoluteYAddressing:   3,
+	IndirectAddressing:    3,
+}
+
+func newDispatchTable() [256]executor {
+	var table [256]executor
+	for i, opcode := range Opcodes {
+		ins := opcode.Instruction
+		if ins == nil {
+			continue
+		}
+
+		_, branching := BranchingInstructions[ins.Name]
+		table[i] = executor{
+			opcode:          opcode,
+			readParams:      paramReader[opcode.Addressing],
+			size:            addressingSize[opcode.Addressing],
+			jump:            ins == Jmp,
+			branching:       branching,
+			branchPageCycle: branching && ins != Jmp && ins != Jsr,
+			execute:         bindExecutor(opcode),
+		}
+	}
+	return table
+}
+
+// bindExecutor returns the inlined execution function for the opcode or nil
+// if it is not supported.
+func bindExecutor(opcode Opcode) func(c *CPU) bool {
+	ins := opcode.Instruction
+	if ins.NoParamFunc != nil {
+		return nil // executed directly without parameter decoding
+	}
+
+	if condition, ok := branchConditions[ins]; ok {
+		return func(c *CPU) bool {
+			if condition(c) {
+				c.takeBranch(relativeAddress(c))
+			}
+			return false
+		}
+	}
+
+	if ins == Jmp && opcode.Addressing == AbsoluteAddressing {
+		return func(c *CPU) bool {
+			c.PC = c.memory.ReadWord(c.PC + 1)
+			return false
+		}
+	}
+
+	resolve, ok := addressResolvers[opcode.Addressing]
+	if !ok {
+		return nil
+	}
+
+	if process, ok := valueReadInstructions[ins]; ok {
+		return func(c *CPU) bool {
+			address, pageCrossed := resolve(c)
+			process(c, c.memory.Read(address))
+			return pageCrossed
+		}
+	}
+
+	if value, ok := valueWriteInstructions[ins]; ok && opcode.Addressing != ImmediateAddressing {
+		return func(c *CPU) bool {
+			address, pageCrossed := resolve(c)
+			c.memory.Write(address, value(c))
+			return pageCrossed
+		}
+	}
+
+	return nil
+}
+
+func resolveImmediate(c *CPU) (uint16, bool) {
+	return c.PC + 1, false
+}
+
+func resolveZeroPage(c *CPU) (uint16, bool) {
+	return uint16(c.memory.Read(c.PC + 1)), false
+}
+
+func resolveZeroPageX(c *CPU) (uint16, bool) {
+	return uint16(c.memory.Read(c.PC+1) + c.X), false
+}
+
+func resolveZeroPageY(c *CPU) (uint16, bool) {
+	return uint16(c.memory.Read(c.PC+1) + c.Y), false
+}
+
+func resolveAbsolute(c *CPU) (uint16, bool) {
+	return c.memory.ReadWord(c.PC + 1), false
+}
+
+func resolveAbsoluteX(c *CPU) (uint16, bool) {
+	return offsetAddress(c.memory.ReadWord(c.PC+1), c.X)
+}
+
+func resolveAbsoluteY(c *CPU) (uint16, bool) {
+	return offsetAddress(c.memory.ReadWord(c.PC+1), c.Y)
+}
+
+func resolveIndirectX(c *CPU) (uint16, bool) {
+	pointer := c.memory.Read(c.PC+1) + c.X
+	return c.memory.ReadWordBug(uint16(pointer)), false
+}
+
+func resolveIndirectY(c *CPU) (uint16, bool) {
+	pointer := c.memory.Read(c.PC + 1)
+	return offsetAddress(c.memory.ReadWordBug(uint16(pointer)), c.Y)
+}
+
+// relativeAddress returns the target address of a relative branch.
+func relativeAddress(c *CPU) uint16 {
+	offset := int8(c.memory.Read(c.PC + 1))
+	return c.PC + 2 + uint16(offset)
+}
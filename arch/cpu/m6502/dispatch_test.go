@@ -0,0 +1,122 @@
+package m6502
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/retroenv/retrogolib/arch/nes"
+	"github.com/retroenv/retrogolib/assert"
+)
+
+// TestDispatchExecutors verifies that the pre-bound executors produce the
+// same results as the generic parameter based execution path.
+func TestDispatchExecutors(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+
+	for b, e := range dispatchTable {
+		if e.execute == nil {
+			continue
+		}
+
+		for range 20 {
+			mem := &testMemory{}
+			rnd.Read(mem.b[:])
+			mem.b[0x8000] = byte(b)
+			mem.b[ResetAddress] = 0x00
+			mem.b[ResetAddress+1] = 0x80
+			genericMem := *mem
+
+			cpu := New(NewMemory(mem))
+			generic := New(NewMemory(&genericMem), WithPreExecutionHook(func(*CPU, *Instruction, ...any) {}))
+			for _, c := range []*CPU{cpu, generic} {
+				c.A = mem.b[0x10]
+				c.X = mem.b[0x11]
+				c.Y = mem.b[0x12]
+				c.setFlags(mem.b[0x13])
+			}
+
+			assert.NoError(t, cpu.Step())
+			assert.NoError(t, generic.Step())
+			assert.Equal(t, generic.State(), cpu.State(), "opcode 0x%02x", b)
+			assert.True(t, genericMem.b == mem.b, "memory differs for opcode 0x%02x", b)
+		}
+	}
+}
+
+// TestPageCrossCycles verifies that the page crossing penalty is counted
+// independent of whether tracing is enabled.
+func TestPageCrossCycles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		code   []byte
+		cycles uint64
+	}{
+		{"lda absolute,x crossing", []byte{0xbd, 0xff, 0x02}, 5},  // lda $02ff,x
+		{"lda absolute,x same page", []byte{0xbd, 0x00, 0x02}, 4}, // lda $0200,x
+		{"sta absolute,x crossing", []byte{0x9d, 0xff, 0x02}, 5},  // sta $02ff,x
+	}
+
+	for _, test := range tests {
+		for _, options := range [][]Option{nil, {WithTracing()}} {
+			memory := NewMemory(&testMemory{})
+			memory.WriteWord(ResetAddress, nes.CodeBaseAddress)
+			for i, b := range test.code {
+				memory.Write(nes.CodeBaseAddress+uint16(i), b)
+			}
+
+			cpu := New(memory, options...)
+			cpu.X = 1
+			start := cpu.Cycles()
+			assert.NoError(t, cpu.Step())
+			assert.Equal(t, test.cycles, cpu.Cycles()-start, "%s, tracing %t", test.name, len(options) > 0)
+		}
+	}
+}
+
+// benchmarkProgram loops over a memory block, reads, modifies and writes
+// every byte of it.
+var benchmarkProgram = []byte{
+	0xa2, 0x00, // ldx #$00
+	0xbd, 0x00, 0x02, // lda $0200,x
+	0x69, 0x01, // adc #$01
+	0x9d, 0x00, 0x03, // sta $0300,x
+	0xe8,       // inx
+	0xd0, 0xf5, // bne $8002
+	0x4c, 0x00, 0x80, // jmp $8000
+}
+
+func benchmarkSetup(options ...Option) *CPU {
+	memory := NewMemory(&testMemory{})
+	memory.WriteWord(ResetAddress, nes.CodeBaseAddress)
+	for i, b := range benchmarkProgram {
+		memory.Write(nes.CodeBaseAddress+uint16(i), b)
+	}
+	return New(memory, options...)
+}
+
+func BenchmarkStep(b *testing.B) {
+	cpu := benchmarkSetup()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := cpu.Step(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStepTracing(b *testing.B) {
+	cpu := benchmarkSetup(WithTracing())
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := cpu.Step(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -7,11 +7,23 @@ import (
 
 // adc - Add with Carry.
 func adc(c *CPU, params ...any) error {
-	a := c.A
 	value, err := c.memory.ReadAddressModes(true, params...)
 	if err != nil {
 		return err
 	}
+	adcValue(c, value)
+	return nil
+}
+
+// adcValue adds the value and the carry to the accumulator.
+func adcValue(c *CPU, value uint8) {
+	if c.decimalMode() {
+		c.adcDecimal(value)
+		return
+	}
+
+	a := c.A
+
 	sum := int(c.A) + int(c.Flags.C) + int(value)
 	c.A = uint8(sum)
 	c.setZN(c.A)
@@ -22,7 +34,6 @@ func adc(c *CPU, params ...any) error {
 		c.Flags.C = 0
 	}
 	c.setV((a^value)&0x80 == 0 && (a^c.A)&0x80 != 0)
-	return nil
 }
 
 // and - AND with accumulator.
@@ -31,9 +42,14 @@ func and(c *CPU, params ...any) error {
 	if err != nil {
 		return err
 	}
+	andValue(c, value)
+	return nil
+}
+
+// andValue ANDs the value with the accumulator.
+func andValue(c *CPU, value uint8) {
 	c.A &= value
 	c.setZN(c.A)
-	return nil
 }
 
 // asl - Arithmetic Shift Left.
@@ -79,10 +95,15 @@ func bit(c *CPU, params ...any) error {
 	if err != nil {
 		return err
 	}
+	bitValue(c, value)
+	return nil
+}
+
+// bitValue tests the bits of the value with the accumulator.
+func bitValue(c *CPU, value uint8) {
 	c.setV((value>>6)&1 == 1)
 	c.setZ(value & c.A)
 	c.setN(value)
-	return nil
 }
 
 // bmi - Branch if Minus.
@@ -151,30 +172,45 @@ func cmp(c *CPU, params ...any) error {
 	if err != nil {
 		return err
 	}
-	c.compare(c.A, val)
+	cmpValue(c, val)
 	return nil
 }
 
+// cmpValue compares the value with the A register.
+func cmpValue(c *CPU, value uint8) {
+	c.compare(c.A, value)
+}
+
 // cpx - Compare the contents of X.
 func cpx(c *CPU, params ...any) error {
 	val, err := c.memory.ReadAddressModes(true, params[0])
 	if err != nil {
 		return err
 	}
-	c.compare(c.X, val)
+	cpxValue(c, val)
 	return nil
 }
 
+// cpxValue compares the value with the X register.
+func cpxValue(c *CPU, value uint8) {
+	c.compare(c.X, value)
+}
+
 // cpy - Compare the contents of Y.
 func cpy(c *CPU, params ...any) error {
 	val, err := c.memory.ReadAddressModes(true, params[0])
 	if err != nil {
 		return err
 	}
-	c.compare(c.Y, val)
+	cpyValue(c, val)
 	return nil
 }
 
+// cpyValue compares the value with the Y register.
+func cpyValue(c *CPU, value uint8) {
+	c.compare(c.Y, value)
+}
+
 // dec - Decrement memory.
 func dec(c *CPU, params ...any) error {
 	val, err := c.memory.ReadAddressModes(false, params...)
@@ -209,9 +245,14 @@ func eor(c *CPU, params ...any) error {
 	if err != nil {
 		return err
 	}
+	eorValue(c, value)
+	return nil
+}
+
+// eorValue exclusive ORs the value with the accumulator.
+func eorValue(c *CPU, value uint8) {
 	c.A ^= value
 	c.setZN(c.A)
-	return nil
 }
 
 // inc - Increments memory.
@@ -271,33 +312,48 @@ func lda(c *CPU, params ...any) error {
 	if err != nil {
 		return err
 	}
-	c.A = val
-	c.setZN(c.A)
+	ldaValue(c, val)
 	return nil
 }
 
+// ldaValue loads the value into the accumulator.
+func ldaValue(c *CPU, value uint8) {
+	c.A = value
+	c.setZN(c.A)
+}
+
 // ldx - Load X Register - load a byte into X.
 func ldx(c *CPU, params ...any) error {
 	val, err := c.memory.ReadAddressModes(true, params...)
 	if err != nil {
 		return err
 	}
-	c.X = val
-	c.setZN(c.X)
+	ldxValue(c, val)
 	return nil
 }
 
+// ldxValue loads the value into the X register.
+func ldxValue(c *CPU, value uint8) {
+	c.X = value
+	c.setZN(c.X)
+}
+
 // ldy - Load Y Register - load a byte into Y.
 func ldy(c *CPU, params ...any) error {
 	val, err := c.memory.ReadAddressModes(true, params...)
 	if err != nil {
 		return err
 	}
-	c.Y = val
-	c.setZN(c.Y)
+	ldyValue(c, val)
 	return nil
 }
 
+// ldyValue loads the value into the Y register.
+func ldyValue(c *CPU, value uint8) {
+	c.Y = value
+	c.setZN(c.Y)
+}
+
 // lsr - Logical Shift Right.
 func lsr(c *CPU, params ...any) error {
 	if hasAccumulatorParam(params...) {
@@ -328,9 +384,14 @@ func ora(c *CPU, params ...any) error {
 	if err != nil {
 		return err
 	}
+	oraValue(c, value)
+	return nil
+}
+
+// oraValue ORs the value with the accumulator.
+func oraValue(c *CPU, value uint8) {
 	c.A |= value
 	c.setZN(c.A)
-	return nil
 }
 
 // pha - Push Accumulator - push A content to stack.
@@ -425,11 +486,23 @@ func rts(c *CPU) error {
 
 // sbc - subtract with Carry.
 func sbc(c *CPU, params ...any) error {
-	a := c.A
 	value, err := c.memory.ReadAddressModes(true, params...)
 	if err != nil {
 		return err
 	}
+	sbcValue(c, value)
+	return nil
+}
+
+// sbcValue subtracts the value and the inverted carry from the accumulator.
+func sbcValue(c *CPU, value uint8) {
+	if c.decimalMode() {
+		c.sbcDecimal(value)
+		return
+	}
+
+	a := c.A
+
 	sub := int(c.A) - int(value) - (1 - int(c.Flags.C))
 	c.A = uint8(sub)
 	c.setZN(c.A)
@@ -440,7 +513,6 @@ func sbc(c *CPU, params ...any) error {
 		c.Flags.C = 0
 	}
 	c.setV((a^value)&0x80 != 0 && (a^c.A)&0x80 != 0)
-	return nil
 }
 
 // sec - Set Carry Flag.
@@ -0,0 +1,153 @@
+package m6502
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// OpcodeEntry describes an opcode for consumers of the exported opcode table.
+type OpcodeEntry struct {
+	Opcode         byte   `json:"opcode"`
+	Mnemonic       string `json:"mnemonic"`
+	Addressing     string `json:"addressing"`
+	Size           int    `json:"size"`
+	Cycles         int    `json:"cycles"`
+	PageCrossCycle bool   `json:"page_cross_cycle"`
+	Unofficial     bool   `json:"unofficial"`
+	FlagsAffected  string `json:"flags_affected"` // status flags in register order, like NVZC
+}
+
+// addressingModeNames contains the exported names of the addressing modes.
+var addressingModeNames = map[AddressingMode]string{
+	ImpliedAddressing:     "implied",
+	AccumulatorAddressing: "accumulator",
+	ImmediateAddressing:   "immediate",
+	AbsoluteAddressing:    "absolute",
+	ZeroPageAddressing:    "zeropage",
+	AbsoluteXAddressing:   "absolute_x",
+	ZeroPageXAddressing:   "zeropage_x",
+	AbsoluteYAddressing:   "absolute_y",
+	ZeroPageYAddressing:   "zeropage_y",
+	IndirectAddressing:    "indirect",
+	IndirectXAddressing:   "indirect_x",
+	IndirectYAddressing:   "indirect_y",
+	RelativeAddressing:    "relative",
+}
+
+// flagsAffected contains the status flags that the instructions modify.
+// Instructions that are not listed do not modify any flag.
+var flagsAffected = map[string]string{
+	Adc.Name: "NVZC",
+	And.Name: "NZ",
+	Asl.Name: "NZC",
+	Bit.Name: "NVZ",
+	Brk.Name: "BI",
+	Clc.Name: "C",
+	Cld.Name: "D",
+	Cli.Name: "I",
+	Clv.Name: "V",
+	Cmp.Name: "NZC",
+	Cpx.Name: "NZC",
+	Cpy.Name: "NZC",
+	Dcp.Name: "NZC",
+	Dec.Name: "NZ",
+	Dex.Name: "NZ",
+	Dey.Name: "NZ",
+	Eor.Name: "NZ",
+	Inc.Name: "NZ",
+	Inx.Name: "NZ",
+	Iny.Name: "NZ",
+	Isc.Name: "NVZC",
+	Lax.Name: "NZ",
+	Lda.Name: "NZ",
+	Ldx.Name: "NZ",
+	Ldy.Name: "NZ",
+	Lsr.Name: "NZC",
+	Ora.Name: "NZ",
+	Pla.Name: "NZ",
+	Plp.Name: "NVDIZC",
+	Rla.Name: "NZC",
+	Rol.Name: "NZC",
+	Ror.Name: "NZC",
+	Rra.Name: "NVZC",
+	Rti.Name: "NVDIZC",
+	Sbc.Name: "NVZC",
+	Sec.Name: "C",
+	Sed.Name: "D",
+	Sei.Name: "I",
+	Slo.Name: "NZC",
+	Sre.Name: "NZC",
+	Tax.Name: "NZ",
+	Tay.Name: "NZ",
+	Tsx.Name: "NZ",
+	Txa.Name: "NZ",
+	Tya.Name: "NZ",
+}
+
+// OpcodeTable returns the entries of all defined opcodes ordered by opcode.
+func OpcodeTable() []OpcodeEntry {
+	entries := make([]OpcodeEntry, 0, len(Opcodes))
+	for i, opcode := range Opcodes {
+		ins := opcode.Instruction
+		if ins == nil {
+			continue
+		}
+
+		entries = append(entries, OpcodeEntry{
+			Opcode:         byte(i),
+			Mnemonic:       ins.Name,
+			Addressing:     addressingModeNames[opcode.Addressing],
+			Size:           int(addressingSize[opcode.Addressing]),
+			Cycles:         int(opcode.Timing),
+			PageCrossCycle: opcode.PageCrossCycle,
+			Unofficial:     ins.Unofficial,
+			FlagsAffected:  flagsAffected[ins.Name],
+		})
+	}
+	return entries
+}
+
+// WriteOpcodesJSON writes the opcode table as indented JSON array to the writer.
+func WriteOpcodesJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(OpcodeTable()); err != nil {
+		return fmt.Errorf("encoding opcode table: %w", err)
+	}
+	return nil
+}
+
+// WriteOpcodesCSV writes the opcode table as CSV with a header row to the writer.
+func WriteOpcodesCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"opcode", "mnemonic", "addressing", "size", "cycles",
+		"page_cross_cycle", "unofficial", "flags_affected"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+
+	for _, entry := range OpcodeTable() {
+		record := []string{
+			fmt.Sprintf("0x%02x", entry.Opcode),
+			entry.Mnemonic,
+			entry.Addressing,
+			strconv.Itoa(entry.Size),
+			strconv.Itoa(entry.Cycles),
+			strconv.FormatBool(entry.PageCrossCycle),
+			strconv.FormatBool(entry.Unofficial),
+			entry.FlagsAffected,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("writing CSV record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("flushing CSV writer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package m6502
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+//go:generate go run ./testdata/fetch
+
+// The functional and interrupt tests of Klaus Dormann's 6502 test suite,
+// assembled with the default configuration and loaded as 64KB memory image.
+// The binaries are not part of this repository due to their license, run
+// go generate to download them from
+// https://github.com/Klaus2m5/6502_65C02_functional_tests into the testdata
+// directory. If the environment variable of functionalTestRequiredEnv is
+// set, missing binaries fail the tests instead of skipping them, which is
+// used by the CI. The success addresses are the addresses of the success traps
+// in the listing files of the prebuilt binaries.
+const (
+	functionalTestFile    = "testdata/6502_functional_test.bin"
+	functionalTestStart   = 0x0400
+	functionalTestSuccess = 0x3469
+
+	interruptTestFile    = "testdata/6502_interrupt_test.bin"
+	interruptTestStart   = 0x0400
+	interruptTestSuccess = 0x06f5
+	interruptTestPort    = 0xbffc // feedback register that drives the interrupt lines
+	interruptTestIrqBit  = 0b01
+	interruptTestNmiBit  = 0b10
+
+	functionalTestMaxInstructions = 100_000_000
+
+	// functionalTestRequiredEnv is the environment variable that makes the
+	// test binaries mandatory.
+	functionalTestRequiredEnv = "M6502_FUNCTIONAL_TESTS"
+)
+
+func TestFunctional(t *testing.T) {
+	t.Parallel()
+
+	mem := &testMemory{}
+	loadTestBinary(t, mem.b[:], functionalTestFile)
+
+	cpu := New(NewMemory(mem))
+	cpu.PC = functionalTestStart
+
+	trap, reason, err := cpu.RunUntilTrap(functionalTestMaxInstructions)
+	assert.NoError(t, err)
+	assert.Equal(t, StopCondition, reason, "test did not end in a trap")
+	assert.Equal(t, functionalTestSuccess, trap, "functional test failed at trap 0x%04X", trap)
+}
+
+func TestInterrupt(t *testing.T) {
+	t.Parallel()
+
+	mem := &interruptTestMemory{}
+	loadTestBinary(t, mem.b[:], interruptTestFile)
+	mem.b[interruptTestPort] = 0
+
+	cpu := New(NewMemory(mem), WithPreExecutionHook(mem.driveInterrupts))
+	mem.cpu = cpu
+	cpu.PC = interruptTestStart
+
+	trap, reason, err := cpu.RunUntilTrap(functionalTestMaxInstructions)
+	assert.NoError(t, err)
+	assert.Equal(t, StopCondition, reason, "test did not end in a trap")
+	assert.Equal(t, interruptTestSuccess, trap, "interrupt test failed at trap 0x%04X", trap)
+}
+
+// loadTestBinary loads a test binary into the memory image, the test is
+// skipped if the binary has not been downloaded and the binaries are not
+// required.
+func loadTestBinary(t *testing.T, image []byte, file string) {
+	t.Helper()
+
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		if os.Getenv(functionalTestRequiredEnv) != "" {
+			t.Fatalf("test binary %s not found, run go generate to download it", file)
+		}
+		t.Skipf("test binary %s not found, run go generate to download it", file)
+	}
+	assert.NoError(t, err)
+	copy(image, data)
+}
+
+// interruptTestMemory implements the feedback register of the interrupt
+// test, which connects bits of the register to the interrupt lines.
+type interruptTestMemory struct {
+	testMemory
+	cpu *CPU
+}
+
+func (m *interruptTestMemory) Write(address uint16, value uint8) {
+	if address == interruptTestPort {
+		old := m.b[address]
+		// NMI is edge triggered
+		if old&interruptTestNmiBit == 0 && value&interruptTestNmiBit != 0 {
+			m.cpu.TriggerNMI()
+		}
+	}
+	m.testMemory.Write(address, value)
+}
+
+// driveInterrupts triggers an interrupt request before every instruction
+// while the level triggered IRQ line is asserted and not masked.
+func (m *interruptTestMemory) driveInterrupts(c *CPU, _ *Instruction, _ ...any) {
+	if m.b[interruptTestPort]&interruptTestIrqBit != 0 && c.Flags.I == 0 {
+		c.TriggerIrq()
+	}
+}
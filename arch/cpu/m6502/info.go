@@ -0,0 +1,78 @@
+package m6502
+
+import (
+	"sync"
+
+	"github.com/retroenv/retrogolib/arch/cpu"
+)
+
+var _ cpu.Instruction = &Instruction{}
+
+// instructionInfos returns the cached metadata of all instructions, which
+// avoids iterating the opcode table for every profiled instruction.
+var instructionInfos = sync.OnceValue(func() map[*Instruction]cpu.InstructionInfo {
+	infos := map[*Instruction]cpu.InstructionInfo{}
+	for _, opcode := range Opcodes {
+		if opcode.Instruction != nil {
+			infos[opcode.Instruction] = opcode.Instruction.Info()
+		}
+	}
+	return infos
+})
+
+// addressingOperands maps the addressing modes to their operand kinds.
+var addressingOperands = map[AddressingMode]cpu.OperandKind{
+	AccumulatorAddressing: cpu.RegisterOperand,
+	ImmediateAddressing:   cpu.ImmediateOperand,
+	AbsoluteAddressing:    cpu.AddressOperand,
+	ZeroPageAddressing:    cpu.AddressOperand,
+	AbsoluteXAddressing:   cpu.AddressOperand | cpu.RegisterOperand,
+	ZeroPageXAddressing:   cpu.AddressOperand | cpu.RegisterOperand,
+	AbsoluteYAddressing:   cpu.AddressOperand | cpu.RegisterOperand,
+	ZeroPageYAddressing:   cpu.AddressOperand | cpu.RegisterOperand,
+	IndirectAddressing:    cpu.IndirectOperand,
+	IndirectXAddressing:   cpu.IndirectOperand | cpu.RegisterOperand,
+	IndirectYAddressing:   cpu.IndirectOperand | cpu.RegisterOperand,
+	RelativeAddressing:    cpu.RelativeOperand,
+}
+
+// Info returns the architecture independent metadata of the instruction.
+// The maximum cycles include the page crossing and taken branch penalties,
+// the memory access combines the memory access of all addressing modes.
+func (ins *Instruction) Info() cpu.InstructionInfo {
+	_, branching := BranchingInstructions[ins.Name]
+	info := cpu.InstructionInfo{
+		Name:       ins.Name,
+		Unofficial: ins.Unofficial,
+		Branching:  branching,
+	}
+
+	for _, opcode := range Opcodes {
+		if opcode.Instruction != ins {
+			continue
+		}
+
+		info.Operands |= addressingOperands[opcode.Addressing]
+		info.Memory |= cpuMemoryAccess(opcode.MemoryAccess())
+
+		minCycles, maxCycles := opcode.CycleRange()
+		if info.MinCycles == 0 || int(minCycles) < info.MinCycles {
+			info.MinCycles = int(minCycles)
+		}
+		info.MaxCycles = max(info.MaxCycles, int(maxCycles))
+	}
+	return info
+}
+
+// cpuMemoryAccess converts the access mode to the architecture independent
+// memory access.
+func cpuMemoryAccess(access AccessMode) cpu.MemoryAccess {
+	var memory cpu.MemoryAccess
+	if access.Reads() {
+		memory |= cpu.MemoryRead
+	}
+	if access.Writes() {
+		memory |= cpu.MemoryWrite
+	}
+	return memory
+}
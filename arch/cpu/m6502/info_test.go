@@ -0,0 +1,144 @@
+package m6502
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/arch/cpu"
+	"github.com/retroenv/retrogolib/arch/nes"
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestInstructionInfo(t *testing.T) {
+	t.Parallel()
+
+	info := Lda.Info()
+	assert.Equal(t, "lda", info.Name)
+	assert.Equal(t, cpu.MemoryRead, info.Memory)
+	assert.True(t, info.Operands.Has(cpu.ImmediateOperand|cpu.AddressOperand|cpu.IndirectOperand|cpu.RegisterOperand))
+	assert.False(t, info.Operands.Has(cpu.RelativeOperand))
+	assert.Equal(t, 2, info.MinCycles)
+	assert.Equal(t, 6, info.MaxCycles)
+
+	info = Bne.Info()
+	assert.True(t, info.Branching)
+	assert.Equal(t, cpu.RelativeOperand, info.Operands)
+	assert.Equal(t, 2, info.MinCycles)
+	assert.Equal(t, 4, info.MaxCycles)
+
+	info = Slo.Info()
+	assert.True(t, info.Unofficial)
+	assert.True(t, info.Memory.Reads())
+	assert.True(t, info.Memory.Writes())
+
+	for _, ins := range []*Instruction{Adc, Eor, Ora, Sbc} {
+		assert.Equal(t, cpu.MemoryRead, ins.Info().Memory, ins.Name)
+	}
+	assert.Equal(t, cpu.MemoryWrite, Sta.Info().Memory)
+	assert.Equal(t, cpu.MemoryReadWrite, Inc.Info().Memory)
+
+	info = Nop.Info()
+	assert.Equal(t, cpu.NoOperand, info.Operands)
+	assert.Equal(t, cpu.NoMemoryAccess, info.Memory)
+	assert.Equal(t, cpu.MemoryRead, NopUnofficial.Info().Memory)
+	assert.Equal(t, cpu.MemoryRead, Jmp.Info().Memory, "jmp indirect reads the pointer")
+	assert.Equal(t, cpu.NoMemoryAccess, Jsr.Info().Memory)
+}
+
+func TestWithCoverage(t *testing.T) {
+	t.Parallel()
+
+	memory := NewMemory(&testMemory{})
+	memory.WriteWord(ResetAddress, nes.CodeBaseAddress)
+	memory.Write(nes.CodeBaseAddress, 0xa9) // lda #$01
+	memory.Write(nes.CodeBaseAddress+1, 0x01)
+	memory.Write(nes.CodeBaseAddress+2, 0xe8) // inx
+
+	coverage := cpu.NewCoverage(0x10000)
+	c := New(memory, WithCoverage(coverage))
+	assert.NoError(t, c.Step())
+	assert.NoError(t, c.Step())
+
+	assert.True(t, coverage.Executed(nes.CodeBaseAddress))
+	assert.False(t, coverage.Executed(nes.CodeBaseAddress+1))
+	assert.True(t, coverage.Executed(nes.CodeBaseAddress+2))
+	assert.Equal(t, map[uint64]uint64{0xa9: 1, 0xe8: 1}, coverage.OpcodeHits())
+}
+
+func TestWithCoverageHookAndFilter(t *testing.T) {
+	t.Parallel()
+
+	memory := NewMemory(&testMemory{})
+	memory.WriteWord(ResetAddress, nes.CodeBaseAddress)
+	memory.Write(nes.CodeBaseAddress, 0xe8)   // inx
+	memory.Write(nes.CodeBaseAddress+1, 0xc8) // iny
+
+	var hooked []uint16
+	coverage := cpu.NewCoverage(0x10000)
+	c := New(memory, WithCoverage(coverage),
+		WithPreExecutionHook(func(c *CPU, _ *Instruction, _ ...any) {
+			hooked = append(hooked, c.PC)
+		}),
+		WithTraceFilter(TraceFilter{ExcludePC: []AddressRange{{Start: nes.CodeBaseAddress, End: nes.CodeBaseAddress}}}))
+	assert.NoError(t, c.Step())
+	assert.NoError(t, c.Step())
+
+	assert.Equal(t, []uint16{nes.CodeBaseAddress + 1}, hooked)
+	assert.True(t, coverage.Executed(nes.CodeBaseAddress))
+	assert.True(t, coverage.Executed(nes.CodeBaseAddress+1))
+}
+
+// readCountingMemory counts the reads of every address, like the reads of a
+// memory mapped register that has side effects.
+type readCountingMemory struct {
+	testMemory
+	reads map[uint16]int
+}
+
+func (m *readCountingMemory) Read(address uint16) uint8 {
+	m.reads[address]++
+	return m.testMemory.Read(address)
+}
+
+func TestWithCoverageReadsOpcodeOnce(t *testing.T) {
+	t.Parallel()
+
+	mem := &readCountingMemory{reads: map[uint16]int{}}
+	memory := NewMemory(mem)
+	memory.WriteWord(ResetAddress, nes.CodeBaseAddress)
+	memory.Write(nes.CodeBaseAddress, 0xe8) // inx
+
+	coverage := cpu.NewCoverage(0x10000)
+	c := New(memory, WithCoverage(coverage))
+	assert.NoError(t, c.Step())
+
+	assert.Equal(t, 1, mem.reads[nes.CodeBaseAddress])
+	assert.Equal(t, map[uint64]uint64{0xe8: 1}, coverage.OpcodeHits())
+}
+
+func TestWithProfile(t *testing.T) {
+	t.Parallel()
+
+	memory := NewMemory(&testMemory{})
+	memory.WriteWord(ResetAddress, nes.CodeBaseAddress)
+	memory.Write(nes.CodeBaseAddress, 0xa9) // lda #$01
+	memory.Write(nes.CodeBaseAddress+1, 0x01)
+	memory.Write(nes.CodeBaseAddress+2, 0x8d) // sta $0200
+	memory.WriteWord(nes.CodeBaseAddress+3, 0x0200)
+	memory.Write(nes.CodeBaseAddress+5, 0xea) // nop
+	memory.Write(nes.CodeBaseAddress+6, 0x1a) // nop unofficial
+
+	profile := cpu.NewProfile()
+	c := New(memory, WithProfile(profile))
+	for range 4 {
+		assert.NoError(t, c.Step())
+	}
+
+	report := profile.Report(-1)
+	assert.Equal(t, 4, report.Instructions)
+	assert.Equal(t, 10, report.Cycles)
+	assert.Equal(t, []cpu.InstructionStats{
+		{Name: "nop", Category: cpu.CategoryOther, Executions: 2, Cycles: 4},
+		{Name: "sta", Category: cpu.CategoryMemoryWrite, Executions: 1, Cycles: 4},
+		{Name: "lda", Category: cpu.CategoryMemoryRead, Executions: 1, Cycles: 2},
+	}, report.OpcodeMix)
+}
@@ -21,15 +21,19 @@ func (c *CPU) TriggerNMI() {
 // CheckInterrupts checks if an interrupt is triggered and executes it.
 // It returns true if an interrupt was executed.
 func (c *CPU) CheckInterrupts() bool {
-	if c.triggerNmi {
+	switch {
+	case c.triggerNmi:
 		c.nmi()
-		return true
-	}
-	if c.triggerIrq {
+	case c.triggerIrq:
 		c.irq()
-		return true
+	default:
+		return false
+	}
+
+	if c.watcher != nil {
+		c.watcher.deliver()
 	}
-	return false
+	return true
 }
 
 func (c *CPU) nmi() {
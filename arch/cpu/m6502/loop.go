@@ -0,0 +1,25 @@
+package m6502
+
+// LoopEvent contains information about a detected tight infinite loop.
+type LoopEvent struct {
+	PC          uint16       // address of the looping instruction
+	Instruction *Instruction // looping jump or branch instruction
+	Cycles      uint64       // CPU cycles at the time of detection
+}
+
+// detectLoop calls the loop handler if the executed instruction jumped to
+// itself and no interrupt is pending that could exit the loop.
+func (c *CPU) detectLoop(e *executor) {
+	if !e.jump && !c.branchTaken {
+		return
+	}
+	if c.triggerNmi || c.triggerIrq {
+		return
+	}
+
+	c.opts.loopHandler(LoopEvent{
+		PC:          c.PC,
+		Instruction: e.opcode.Instruction,
+		Cycles:      c.cycles,
+	})
+}
@@ -0,0 +1,51 @@
+package m6502
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/arch/nes"
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestLoopDetection(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    []byte
+		ins     *Instruction
+		trigger bool
+	}{
+		{name: "jmp to self", code: []byte{0x4c, 0x00, 0x80}, ins: Jmp},
+		{name: "branch to self", code: []byte{0xd0, 0xfe}, ins: Bne},
+		{name: "pending interrupt", code: []byte{0x4c, 0x00, 0x80}, trigger: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var events []LoopEvent
+			memory := NewMemory(&testMemory{})
+			memory.WriteWord(ResetAddress, nes.CodeBaseAddress)
+			for i, b := range test.code {
+				memory.Write(nes.CodeBaseAddress+uint16(i), b)
+			}
+
+			cpu := New(memory, WithLoopDetection(func(event LoopEvent) {
+				events = append(events, event)
+			}))
+			if test.trigger {
+				cpu.TriggerNMI()
+			}
+
+			assert.NoError(t, cpu.Step())
+			assert.NoError(t, cpu.Step())
+			assert.Equal(t, nes.CodeBaseAddress, cpu.PC)
+
+			if test.ins == nil {
+				assert.Len(t, events, 0)
+				return
+			}
+			assert.Len(t, events, 2)
+			assert.Equal(t, nes.CodeBaseAddress, events[0].PC)
+			assert.Equal(t, test.ins, events[0].Instruction)
+		})
+	}
+}
@@ -24,13 +24,44 @@ type BasicMemory interface {
 	Write(address uint16, value uint8)
 }
 
+// AddressBusWidth6507 is the width in bits of the address bus of the 6507 CPU.
+const AddressBusWidth6507 = 13
+
+// maskedMemory limits all addresses to the width of the address bus.
+type maskedMemory struct {
+	BasicMemory
+	mask uint16
+}
+
+// Read reads a byte from the mirrored address.
+func (m maskedMemory) Read(address uint16) uint8 {
+	return m.BasicMemory.Read(address & m.mask)
+}
+
+// Write writes a byte to the mirrored address.
+func (m maskedMemory) Write(address uint16, value uint8) {
+	m.BasicMemory.Write(address&m.mask, value)
+}
+
 // Memory represents an advanced memory access interface.
 type Memory struct {
 	BasicMemory
 }
 
-// NewMemory returns a new memory instance.
-func NewMemory(mem BasicMemory) *Memory {
+// NewMemory returns a new memory instance. If mirror or register region
+// options are passed, the accesses are mapped before they reach the passed
+// memory.
+func NewMemory(mem BasicMemory, options ...MemoryOption) *Memory {
+	var opts MemoryOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	if len(opts.mirrors) > 0 || len(opts.registers) > 0 {
+		mem = &mappedMemory{
+			BasicMemory: mem,
+			opts:        opts,
+		}
+	}
 	return &Memory{BasicMemory: mem}
 }
 
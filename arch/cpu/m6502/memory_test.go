@@ -73,3 +73,52 @@ func TestWriteWord(t *testing.T) {
 	m.WriteWord(0, 0x201)
 	assert.Equal(t, 0x201, m.ReadWord(0))
 }
+
+func TestAddressBusWidth6507(t *testing.T) {
+	t.Parallel()
+	mem := &testMemory{}
+	mem.b[0x1FFC] = 0x00 // reset vector is read from the mirrored address
+	mem.b[0x1FFD] = 0xF0
+	copy(mem.b[0x1000:], []byte{
+		0xad, 0x80, 0xe0, // lda $e080
+		0x8d, 0x81, 0xf0, // sta $f081
+	})
+	mem.b[0x0080] = 0x42
+
+	cpu := New(NewMemory(mem), WithAddressBusWidth(AddressBusWidth6507))
+	assert.Equal(t, 0xF000, cpu.PC)
+
+	assert.NoError(t, cpu.Step())
+	assert.Equal(t, 0x42, cpu.A)
+	assert.NoError(t, cpu.Step())
+	assert.Equal(t, 0x42, mem.b[0x1081])
+	assert.Equal(t, 0x42, cpu.Memory().Read(0xF081))
+}
+
+func TestMemoryMirror(t *testing.T) {
+	t.Parallel()
+
+	var registerWrites []uint16
+	mem := &testMemory{}
+	m := NewMemory(mem,
+		WithMirror(0x0000, 0x1FFF, 0x0800),
+		WithMirror(0x2000, 0x3FFF, 8),
+		WithRegisterRegion(0x2000, 0x2007, func(address uint16) uint8 {
+			return uint8(address)
+		}, func(address uint16, _ uint8) {
+			registerWrites = append(registerWrites, address)
+		}),
+	)
+
+	m.Write(0x1801, 0x42)
+	assert.Equal(t, 0x42, mem.b[0x0001])
+	assert.Equal(t, 0x42, m.Read(0x0801))
+
+	assert.Equal(t, 0x02, m.Read(0x3FFA))
+	m.Write(0x2008, 1)
+	assert.Equal(t, []uint16{0x2000}, registerWrites)
+	assert.Equal(t, 0, mem.b[0x2000])
+
+	m.Write(0x4000, 0x11)
+	assert.Equal(t, 0x11, mem.b[0x4000])
+}
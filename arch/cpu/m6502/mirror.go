@@ -0,0 +1,105 @@
+package m6502
+
+// MemoryOptions contains options for the memory.
+type MemoryOptions struct {
+	mirrors   []mirror
+	registers []registerRegion
+}
+
+// MemoryOption defines a memory parameter.
+type MemoryOption func(*MemoryOptions)
+
+// mirror maps the addresses of a range onto its first size bytes.
+type mirror struct {
+	AddressRange
+	size uint16
+}
+
+// registerRegion forwards accesses of an address range to callbacks.
+type registerRegion struct {
+	AddressRange
+	read  func(address uint16) uint8
+	write func(address uint16, value uint8)
+}
+
+// WithMirror mirrors the first size bytes of the address range start-end
+// through the whole range. For example the 2KB RAM of the NES is mirrored
+// with WithMirror(0x0000, 0x1FFF, 0x0800) and the PPU registers with
+// WithMirror(0x2000, 0x3FFF, 8).
+func WithMirror(start, end, size uint16) func(*MemoryOptions) {
+	return func(options *MemoryOptions) {
+		if size == 0 {
+			return
+		}
+		options.mirrors = append(options.mirrors, mirror{
+			AddressRange: AddressRange{Start: start, End: end},
+			size:         size,
+		})
+	}
+}
+
+// WithRegisterRegion forwards reads and writes of the address range to the
+// callbacks instead of the wrapped memory, which allows mapping device
+// registers. The callbacks receive the address after mirroring, a nil
+// callback ignores the access type and reads return 0.
+func WithRegisterRegion(start, end uint16, read func(address uint16) uint8,
+	write func(address uint16, value uint8)) func(*MemoryOptions) {
+	return func(options *MemoryOptions) {
+		options.registers = append(options.registers, registerRegion{
+			AddressRange: AddressRange{Start: start, End: end},
+			read:         read,
+			write:        write,
+		})
+	}
+}
+
+// mappedMemory applies mirrors and register regions before accessing the
+// wrapped memory.
+type mappedMemory struct {
+	BasicMemory
+	opts MemoryOptions
+}
+
+// Read reads a byte from the mapped address.
+func (m *mappedMemory) Read(address uint16) uint8 {
+	address = m.resolve(address)
+	if region := m.register(address); region != nil {
+		if region.read == nil {
+			return 0
+		}
+		return region.read(address)
+	}
+	return m.BasicMemory.Read(address)
+}
+
+// Write writes a byte to the mapped address.
+func (m *mappedMemory) Write(address uint16, value uint8) {
+	address = m.resolve(address)
+	if region := m.register(address); region != nil {
+		if region.write != nil {
+			region.write(address, value)
+		}
+		return
+	}
+	m.BasicMemory.Write(address, value)
+}
+
+// resolve returns the address that a mirrored address maps to.
+func (m *mappedMemory) resolve(address uint16) uint16 {
+	for _, mirror := range m.opts.mirrors {
+		if mirror.Contains(address) {
+			return mirror.Start + (address-mirror.Start)%mirror.size
+		}
+	}
+	return address
+}
+
+// register returns the register region that contains the address.
+func (m *mappedMemory) register(address uint16) *registerRegion {
+	for i := range m.opts.registers {
+		if m.opts.registers[i].Contains(address) {
+			return &m.opts.registers[i]
+		}
+	}
+	return nil
+}
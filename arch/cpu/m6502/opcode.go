@@ -310,3 +310,46 @@ func (opcode Opcode) ReadWritesMemory(memoryReadWriteInstructions map[string]str
 	_, ok := memoryReadWriteInstructions[opcode.Instruction.Name]
 	return ok
 }
+
+// MemoryAccess returns the type of memory access of the opcode through its
+// operand. Opcodes with an addressing mode that does not address memory,
+// like immediate or accumulator addressing, return NoAccess.
+func (opcode Opcode) MemoryAccess() AccessMode {
+	switch opcode.Addressing {
+	case NoAddressing, ImmediateAddressing, ImpliedAddressing, AccumulatorAddressing, RelativeAddressing:
+		return NoAccess
+	}
+	if opcode.Instruction.Name == Jmp.Name && opcode.Addressing != IndirectAddressing {
+		return NoAccess // the jump target is not a memory access
+	}
+
+	return instructionMemoryAccess[opcode.Instruction.Name]
+}
+
+// CyclesBase returns the cycles of the opcode without any penalties.
+func (opcode Opcode) CyclesBase() byte {
+	return opcode.Timing
+}
+
+// CyclesPageCross returns the additional cycles when the indexed address
+// crosses a page boundary. For branches the penalty only applies when the
+// branch is taken and the target is located in a different page.
+func (opcode Opcode) CyclesPageCross() byte {
+	if opcode.PageCrossCycle || opcode.Addressing == RelativeAddressing {
+		return 1
+	}
+	return 0
+}
+
+// CyclesBranchTaken returns the additional cycles of a taken branch.
+func (opcode Opcode) CyclesBranchTaken() byte {
+	if opcode.Addressing == RelativeAddressing {
+		return 1
+	}
+	return 0
+}
+
+// CycleRange returns the minimum and maximum cycles that the opcode can take.
+func (opcode Opcode) CycleRange() (byte, byte) {
+	return opcode.Timing, opcode.Timing + opcode.CyclesPageCross() + opcode.CyclesBranchTaken()
+}
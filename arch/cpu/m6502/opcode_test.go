@@ -1,6 +1,9 @@
 package m6502
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/retroenv/retrogolib/assert"
@@ -25,3 +28,85 @@ func TestVerifyOpcodes(t *testing.T) {
 		assert.Equal(t, b, info.Opcode)
 	}
 }
+
+func TestOpcodeCycles(t *testing.T) {
+	t.Parallel()
+
+	lda := Opcodes[0xbd] // lda absolute,x
+	assert.Equal(t, 4, lda.CyclesBase())
+	assert.Equal(t, 1, lda.CyclesPageCross())
+	assert.Equal(t, 0, lda.CyclesBranchTaken())
+
+	sta := Opcodes[0x9d] // sta absolute,x
+	assert.Equal(t, 0, sta.CyclesPageCross())
+	minCycles, maxCycles := sta.CycleRange()
+	assert.Equal(t, 5, minCycles)
+	assert.Equal(t, 5, maxCycles)
+
+	bne := Opcodes[0xd0]
+	assert.Equal(t, 1, bne.CyclesBranchTaken())
+	assert.Equal(t, 1, bne.CyclesPageCross())
+	minCycles, maxCycles = bne.CycleRange()
+	assert.Equal(t, 2, minCycles)
+	assert.Equal(t, 4, maxCycles)
+}
+
+func TestOpcodeMemoryAccess(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, NoAccess, Opcodes[0x69].MemoryAccess())        // adc #imm
+	assert.Equal(t, ReadAccess, Opcodes[0x65].MemoryAccess())      // adc zeropage
+	assert.Equal(t, WriteAccess, Opcodes[0x8d].MemoryAccess())     // sta absolute
+	assert.Equal(t, NoAccess, Opcodes[0x0a].MemoryAccess())        // asl a
+	assert.Equal(t, ReadWriteAccess, Opcodes[0x0e].MemoryAccess()) // asl absolute
+	assert.Equal(t, NoAccess, Opcodes[0xe8].MemoryAccess())        // inx
+	assert.Equal(t, NoAccess, Opcodes[0x4c].MemoryAccess())        // jmp absolute
+	assert.Equal(t, ReadAccess, Opcodes[0x6c].MemoryAccess())      // jmp indirect
+	assert.Equal(t, NoAccess, Opcodes[0xea].MemoryAccess())        // nop
+	assert.Equal(t, NoAccess, Opcodes[0x80].MemoryAccess())        // nop #imm unofficial
+	assert.Equal(t, ReadAccess, Opcodes[0x04].MemoryAccess())      // nop zeropage unofficial
+	assert.Equal(t, ReadAccess, Opcodes[0x1c].MemoryAccess())      // nop absolute,x unofficial
+
+	access := Opcodes[0x07].MemoryAccess() // slo zeropage
+	assert.True(t, access.Reads())
+	assert.True(t, access.Writes())
+}
+
+func TestOpcodeTable(t *testing.T) {
+	t.Parallel()
+
+	entries := OpcodeTable()
+	var adc OpcodeEntry
+	for _, entry := range entries {
+		assert.NotEqual(t, "", entry.Addressing, "opcode %02x", entry.Opcode)
+		assert.True(t, entry.Size > 0, "opcode %02x", entry.Opcode)
+		if entry.Opcode == 0x7d {
+			adc = entry
+		}
+	}
+	assert.Equal(t, OpcodeEntry{
+		Opcode:         0x7d,
+		Mnemonic:       "adc",
+		Addressing:     "absolute_x",
+		Size:           3,
+		Cycles:         4,
+		PageCrossCycle: true,
+		FlagsAffected:  "NVZC",
+	}, adc)
+}
+
+func TestWriteOpcodes(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteOpcodesCSV(&buf))
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, "opcode,mnemonic,addressing,size,cycles,page_cross_cycle,unofficial,flags_affected", lines[0])
+	assert.Equal(t, "0x00,brk,implied,1,7,false,false,BI", lines[1])
+
+	buf.Reset()
+	assert.NoError(t, WriteOpcodesJSON(&buf))
+	var entries []OpcodeEntry
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entries))
+	assert.Equal(t, OpcodeTable(), entries)
+}
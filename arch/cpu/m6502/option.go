@@ -1,11 +1,24 @@
 package m6502
 
+import "github.com/retroenv/retrogolib/arch/cpu"
+
 type preExecutionHook func(cpu *CPU, ins *Instruction, params ...any)
 
 // Options contains options for the CPU.
 type Options struct {
 	tracing          bool
 	preExecutionHook preExecutionHook
+	loopHandler      func(LoopEvent)
+	noDecimalMode    bool
+	traceFilter      *TraceFilter
+	addressMask      uint16
+	writeHandler     func(writes []MemoryWrite)
+	writeRanges      []AddressRange
+	profile          *cpu.Profile
+	coverage         *cpu.Coverage
+
+	powerOnState       *PowerOnState
+	resetClearsDecimal bool
 }
 
 // Option defines a Start parameter.
@@ -34,3 +47,74 @@ func WithPreExecutionHook(hook preExecutionHook) func(*Options) {
 		options.preExecutionHook = hook
 	}
 }
+
+// WithProfile records every executed instruction in the profile. The
+// recorded cycles include the page crossing and taken branch penalties but
+// not the cycles that the CPU was stalled for.
+func WithProfile(profile *cpu.Profile) func(*Options) {
+	return func(options *Options) {
+		options.profile = profile
+	}
+}
+
+// WithCoverage records every executed opcode and its address in the
+// coverage, which should be created with an address space of 0x10000.
+// The recording is independent of the pre execution hook and the trace
+// filter, the opcode that the CPU fetched is recorded without reading the
+// memory again.
+func WithCoverage(coverage *cpu.Coverage) func(*Options) {
+	return func(options *Options) {
+		options.coverage = coverage
+	}
+}
+
+// WithTraceFilter sets a filter that limits the instructions that the pre
+// execution hook is called for.
+func WithTraceFilter(filter TraceFilter) func(*Options) {
+	return func(options *Options) {
+		options.traceFilter = &filter
+	}
+}
+
+// WithoutDecimalMode disables the decimal mode of the ADC and SBC instructions,
+// the decimal flag can still be set but has no effect on the arithmetic. This
+// matches the behavior of the Ricoh 2A03 CPU of the NES.
+func WithoutDecimalMode() func(*Options) {
+	return func(options *Options) {
+		options.noDecimalMode = true
+	}
+}
+
+// WithLoopDetection enables the detection of tight infinite loops, like a jump
+// or a taken branch to the instruction itself while no interrupt is pending.
+// The handler is called every time that the looping instruction is executed.
+func WithLoopDetection(handler func(LoopEvent)) func(*Options) {
+	return func(options *Options) {
+		options.loopHandler = handler
+	}
+}
+
+// WithAddressBusWidth limits the address bus of the CPU to the given amount
+// of bits, all memory accesses including the interrupt vector reads are
+// mirrored into the resulting address space. This matches the behavior of
+// the 6507 CPU of the Atari 2600, see AddressBusWidth6507.
+func WithAddressBusWidth(bits int) func(*Options) {
+	return func(options *Options) {
+		if bits > 0 && bits < 16 {
+			options.addressMask = 1<<bits - 1
+		}
+	}
+}
+
+// WithWriteNotification enables notifications about writes to the given
+// address ranges, which allows models of memory mapped devices to process
+// register updates without a callback for every write. The writes of an
+// instruction are coalesced per address and passed to the handler after the
+// instruction or interrupt was executed. The slice is only valid during the
+// handler call.
+func WithWriteNotification(handler func(writes []MemoryWrite), ranges ...AddressRange) func(*Options) {
+	return func(options *Options) {
+		options.writeHandler = handler
+		options.writeRanges = ranges
+	}
+}
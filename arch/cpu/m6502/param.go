@@ -1,9 +1,5 @@
 package m6502
 
-import (
-	"fmt"
-)
-
 type paramReaderFunc func(c *CPU) ([]any, []byte, bool)
 
 var paramReader = map[AddressingMode]paramReaderFunc{
@@ -22,18 +18,6 @@ var paramReader = map[AddressingMode]paramReaderFunc{
 	IndirectYAddressing:   paramReaderIndirectY,
 }
 
-// readOpParams reads the opcode parameters after the first opcode byte
-// and translates it into emulator specific types.
-func readOpParams(c *CPU, addressing AddressingMode) ([]any, []byte, bool, error) {
-	fun, ok := paramReader[addressing]
-	if !ok {
-		return nil, nil, false, fmt.Errorf("unsupported addressing mode %00x", addressing)
-	}
-
-	params, opcodes, pageCrossed := fun(c)
-	return params, opcodes, pageCrossed, nil
-}
-
 func paramReaderImplied(_ *CPU) ([]any, []byte, bool) {
 	return nil, nil, false
 }
@@ -0,0 +1,75 @@
+package m6502
+
+// PowerOnState contains the register values of the CPU after power on.
+// The values differ between CPU revisions and are undefined for some
+// registers on real hardware.
+type PowerOnState struct {
+	A     uint8
+	X     uint8
+	Y     uint8
+	SP    uint8
+	Flags uint8
+}
+
+// DefaultPowerOnState is the power on state that is used unless it is
+// overwritten by the WithPowerOnState option.
+var DefaultPowerOnState = PowerOnState{
+	SP:    InitialStack,
+	Flags: initialFlags,
+}
+
+// WithPowerOnState sets the register values that the CPU starts with.
+func WithPowerOnState(state PowerOnState) func(*Options) {
+	return func(options *Options) {
+		options.powerOnState = &state
+	}
+}
+
+// WithResetClearsDecimal clears the decimal flag on reset, which matches
+// the behavior of the CMOS 65C02. The NMOS 6502 leaves the flag unchanged.
+func WithResetClearsDecimal() func(*Options) {
+	return func(options *Options) {
+		options.resetClearsDecimal = true
+	}
+}
+
+// Reset executes the reset sequence of the CPU. Like on real hardware the
+// registers keep their values, the stack pointer is decremented by 3 without
+// writing to the stack, interrupts get disabled and the program counter is
+// loaded from the reset vector. Pending stall cycles and interrupts are
+// discarded.
+func (c *CPU) Reset() {
+	c.mu.Lock()
+	c.triggerIrq = false
+	c.triggerNmi = false
+	c.irqRunning = false
+	c.nmiRunning = false
+	c.mu.Unlock()
+
+	c.stallCycles = 0
+	c.SP -= 3
+	c.Flags.I = 1
+	if c.opts.resetClearsDecimal {
+		c.Flags.D = 0
+	}
+	c.cycles += initialCycles
+
+	c.nmiAddress = c.memory.ReadWordBug(NMIAddress)
+	c.PC = c.memory.ReadWordBug(ResetAddress)
+	c.irqAddress = c.memory.ReadWordBug(IrqAddress)
+}
+
+// powerOn sets the power on state of the registers.
+func (c *CPU) powerOn() {
+	state := DefaultPowerOnState
+	if c.opts.powerOnState != nil {
+		state = *c.opts.powerOnState
+	}
+
+	c.A = state.A
+	c.X = state.X
+	c.Y = state.Y
+	c.SP = state.SP
+	c.setFlags(state.Flags)
+	c.cycles = initialCycles
+}
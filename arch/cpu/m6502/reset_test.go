@@ -0,0 +1,53 @@
+package m6502
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/arch/nes"
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestPowerOnState(t *testing.T) {
+	t.Parallel()
+
+	cpu := cpuTestSetup()
+	assert.Equal(t, InitialStack, cpu.SP)
+	assert.Equal(t, initialFlags, cpu.GetFlags())
+
+	memory := NewMemory(&testMemory{})
+	cpu = New(memory, WithPowerOnState(PowerOnState{
+		A:     0xaa,
+		SP:    0x00,
+		Flags: 0b0011_0100,
+	}))
+	assert.Equal(t, 0xaa, cpu.A)
+	assert.Equal(t, 0x00, cpu.SP)
+	assert.Equal(t, 0b0011_0100, cpu.GetFlags())
+	assert.Equal(t, initialCycles, cpu.Cycles())
+}
+
+func TestReset(t *testing.T) {
+	t.Parallel()
+
+	cpu := cpuTestSetup()
+	cpu.A = 0x12
+	cpu.PC = 0x1234
+	cpu.Flags.I = 0
+	cpu.Flags.D = 1
+	cpu.TriggerNMI()
+
+	cpu.Reset()
+	assert.Equal(t, nes.CodeBaseAddress, cpu.PC)
+	assert.Equal(t, 0x12, cpu.A)
+	assert.Equal(t, InitialStack-3, cpu.SP)
+	assert.Equal(t, 1, cpu.Flags.I)
+	assert.Equal(t, 1, cpu.Flags.D)
+	assert.Equal(t, 2*initialCycles, cpu.Cycles())
+	assert.False(t, cpu.CheckInterrupts())
+
+	memory := NewMemory(&testMemory{})
+	cpu = New(memory, WithResetClearsDecimal())
+	cpu.Flags.D = 1
+	cpu.Reset()
+	assert.Equal(t, 0, cpu.Flags.D)
+}
@@ -0,0 +1,70 @@
+package m6502
+
+import "fmt"
+
+// StopReason defines why RunUntil stopped the execution.
+type StopReason int
+
+const (
+	// StopCondition means that the condition was satisfied.
+	StopCondition StopReason = iota
+	// StopInstructionLimit means that the maximum amount of instructions was executed.
+	StopInstructionLimit
+	// StopError means that the execution of an instruction failed.
+	StopError
+)
+
+// String returns the name of the stop reason.
+func (r StopReason) String() string {
+	switch r {
+	case StopCondition:
+		return "condition"
+	case StopInstructionLimit:
+		return "instruction limit"
+	case StopError:
+		return "error"
+	default:
+		return fmt.Sprintf("StopReason(%d)", int(r))
+	}
+}
+
+// RunUntil executes instructions until the condition returns true, the
+// maximum amount of instructions was executed or an instruction fails.
+// The condition is checked before every instruction, a maxInstructions value
+// of 0 disables the limit. Like Step, pending interrupts are not processed.
+func (c *CPU) RunUntil(condition func(c *CPU) bool, maxInstructions uint64) (StopReason, error) {
+	for executed := uint64(0); maxInstructions == 0 || executed < maxInstructions; executed++ {
+		if condition(c) {
+			return StopCondition, nil
+		}
+		if err := c.Step(); err != nil {
+			return StopError, fmt.Errorf("executing instruction at 0x%04X: %w", c.PC, err)
+		}
+	}
+
+	if condition(c) {
+		return StopCondition, nil
+	}
+	return StopInstructionLimit, nil
+}
+
+// RunUntilTrap executes instructions until the CPU is trapped in a jump or
+// branch to itself and returns the address of the trap. Test suites like
+// the one of Klaus Dormann use traps to signal success or failure. Unlike
+// RunUntil, pending interrupts are processed before every instruction and
+// a trap with a pending interrupt is not reported, as the interrupt exits
+// it. A maxInstructions value of 0 disables the limit.
+func (c *CPU) RunUntilTrap(maxInstructions uint64) (uint16, StopReason, error) {
+	for executed := uint64(0); maxInstructions == 0 || executed < maxInstructions; executed++ {
+		c.CheckInterrupts()
+
+		pc := c.PC
+		if err := c.Step(); err != nil {
+			return pc, StopError, fmt.Errorf("executing instruction at 0x%04X: %w", pc, err)
+		}
+		if c.PC == pc && !c.rdyLow && !c.triggerNmi && !c.triggerIrq {
+			return pc, StopCondition, nil
+		}
+	}
+	return c.PC, StopInstructionLimit, nil
+}
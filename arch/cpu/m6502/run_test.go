@@ -0,0 +1,63 @@
+package m6502
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/arch/nes"
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestRunUntil(t *testing.T) {
+	t.Parallel()
+
+	cpu := cpuTestSetup()
+	code := []byte{
+		0xe8,       // inx
+		0xe0, 0x05, // cpx #$05
+		0xd0, 0xfb, // bne inx
+		0x4c, 0x05, 0x80, // jmp $8005
+	}
+	for i, b := range code {
+		cpu.memory.Write(nes.CodeBaseAddress+uint16(i), b)
+	}
+
+	reason, err := cpu.RunUntil(func(c *CPU) bool { return c.PC == nes.CodeBaseAddress+5 }, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, StopCondition, reason)
+	assert.Equal(t, 5, cpu.X)
+
+	reason, err = cpu.RunUntil(func(c *CPU) bool { return false }, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, StopInstructionLimit, reason)
+
+	cpu.PC = 0x0200
+	cpu.memory.Write(0x0200, 0x02) // unsupported opcode
+	reason, err = cpu.RunUntil(func(c *CPU) bool { return false }, 0)
+	assert.Equal(t, StopError, reason)
+	assert.True(t, err != nil)
+}
+
+func TestRunUntilTrap(t *testing.T) {
+	t.Parallel()
+
+	cpu := cpuTestSetup()
+	code := []byte{
+		0xe8,       // inx
+		0xe0, 0x05, // cpx #$05
+		0xd0, 0xfb, // bne inx
+		0x4c, 0x05, 0x80, // jmp $8005
+	}
+	for i, b := range code {
+		cpu.memory.Write(nes.CodeBaseAddress+uint16(i), b)
+	}
+
+	_, reason, err := cpu.RunUntilTrap(10)
+	assert.NoError(t, err)
+	assert.Equal(t, StopInstructionLimit, reason)
+
+	trap, reason, err := cpu.RunUntilTrap(0)
+	assert.NoError(t, err)
+	assert.Equal(t, StopCondition, reason)
+	assert.Equal(t, nes.CodeBaseAddress+5, trap)
+	assert.Equal(t, 5, cpu.X)
+}
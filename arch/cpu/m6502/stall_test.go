@@ -0,0 +1,38 @@
+package m6502
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/arch/nes"
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestStallCycles(t *testing.T) {
+	t.Parallel()
+	cpu := cpuTestSetup()
+	cpu.memory.Write(cpu.PC, 0xea) // nop
+
+	cpu.StallCycles(513)
+	cpu.StallCycles(4)
+	assert.NoError(t, cpu.Step())
+	assert.Equal(t, initialCycles+513+4+2, cpu.Cycles())
+	assert.Equal(t, nes.CodeBaseAddress+1, cpu.PC)
+}
+
+func TestRDY(t *testing.T) {
+	t.Parallel()
+	cpu := cpuTestSetup()
+	cpu.memory.Write(cpu.PC, 0xea) // nop
+
+	cpu.SetRDY(false)
+	assert.True(t, cpu.Halted())
+	assert.NoError(t, cpu.Step())
+	assert.NoError(t, cpu.Step())
+	assert.Equal(t, initialCycles+2, cpu.Cycles())
+	assert.Equal(t, nes.CodeBaseAddress, cpu.PC)
+
+	cpu.SetRDY(true)
+	assert.False(t, cpu.Halted())
+	assert.NoError(t, cpu.Step())
+	assert.Equal(t, nes.CodeBaseAddress+1, cpu.PC)
+}
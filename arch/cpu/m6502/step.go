@@ -14,100 +14,140 @@ type TraceStep struct {
 	PageCrossed bool
 }
 
-// Step executes the next instruction in the CPU.
+// Step executes the next instruction in the CPU. Pending stall cycles are
+// added to the cycle counter first, if the RDY line is low only a single
+// halted cycle is consumed.
 func (c *CPU) Step() error {
+	if c.rdyLow {
+		c.cycles++
+		return nil
+	}
+	if c.stallCycles > 0 {
+		c.cycles += c.stallCycles
+		c.stallCycles = 0
+	}
+
 	oldPC := c.PC
-	opcode, err := c.decodeNextInstruction()
+	startCycles := c.cycles
+	c.branchTaken = false
+	e, err := c.decodeNextInstruction()
 	if err != nil {
 		return err
 	}
 
-	c.cycles += uint64(opcode.Timing)
+	c.cycles += uint64(e.opcode.Timing)
+	if c.opts.coverage != nil {
+		c.opts.coverage.Record(uint64(oldPC), uint64(c.opcode))
+	}
 
-	ins := opcode.Instruction
+	pageCrossed, err := c.execute(e, oldPC)
+	if err != nil {
+		return err
+	}
+	if pageCrossed && e.opcode.PageCrossCycle {
+		c.cycles++
+	}
+	c.updatePC(e, oldPC)
+
+	if c.opts.profile != nil {
+		c.opts.profile.RecordInfo(uint64(oldPC), instructionInfos()[e.opcode.Instruction], c.cycles-startCycles)
+	}
+	if c.watcher != nil {
+		c.watcher.deliver()
+	}
+	if c.opts.loopHandler != nil && c.PC == oldPC {
+		c.detectLoop(e)
+	}
+	return nil
+}
+
+// execute runs the instruction and returns whether indexing crossed a page.
+// The pre-bound executor is used unless tracing or the pre execution hook
+// need the decoded instruction parameters.
+func (c *CPU) execute(e *executor, oldPC uint16) (bool, error) {
+	ins := e.opcode.Instruction
 	if ins.NoParamFunc != nil {
 		if c.opts.tracing {
 			c.TraceStep.PageCrossed = false
 		}
-		if c.opts.preExecutionHook != nil {
+		if c.opts.preExecutionHook != nil && c.traceInstruction(oldPC, ins) {
 			c.opts.preExecutionHook(c, ins)
 		}
 
 		if err := ins.NoParamFunc(c); err != nil {
-			return fmt.Errorf("executing no param instruction %s: %w", ins.Name, err)
+			return false, fmt.Errorf("executing no param instruction %s: %w", ins.Name, err)
 		}
-		c.updatePC(ins, oldPC, 1)
-		return nil
+		return false, nil
 	}
 
-	params, operands, pageCrossed, err := readOpParams(c, opcode.Addressing)
-	if err != nil {
-		return fmt.Errorf("reading opcode params: %w", err)
+	if e.execute != nil && !c.opts.tracing && c.opts.preExecutionHook == nil {
+		return e.execute(c), nil
 	}
+
+	params, operands, pageCrossed := e.readParams(c)
 	if c.opts.tracing {
 		c.TraceStep.OpcodeOperands = append(c.TraceStep.OpcodeOperands, operands...)
 		c.TraceStep.PageCrossed = pageCrossed
 	}
-	if c.opts.preExecutionHook != nil {
+	if c.opts.preExecutionHook != nil && c.traceInstruction(oldPC, ins, params...) {
 		c.opts.preExecutionHook(c, ins, params...)
 	}
 
-	if pageCrossed && c.TraceStep.Opcode.PageCrossCycle {
-		c.cycles++
+	if err := ins.ParamFunc(c, params...); err != nil {
+		return false, fmt.Errorf("executing param instruction %s: %w", ins.Name, err)
 	}
+	return pageCrossed, nil
+}
 
-	opcodeLen := len(operands) + 1
-
-	if err := ins.ParamFunc(c, params...); err != nil {
-		return fmt.Errorf("executing param instruction %s: %w", ins.Name, err)
+// traceInstruction returns whether the instruction passes the trace filter.
+func (c *CPU) traceInstruction(address uint16, ins *Instruction, params ...any) bool {
+	if c.opts.traceFilter == nil {
+		return true
 	}
-	c.updatePC(ins, oldPC, opcodeLen)
-	return nil
+	return c.opts.traceFilter.matches(address, ins, params)
 }
 
 // decodeNextInstruction decodes the current instruction at the program counter.
-func (c *CPU) decodeNextInstruction() (Opcode, error) {
+func (c *CPU) decodeNextInstruction() (*executor, error) {
 	b := c.memory.Read(c.PC)
-	opcode := Opcodes[b]
-	if opcode.Instruction == nil {
-		return Opcode{}, fmt.Errorf("unsupported opcode %00x", b)
+	e := &dispatchTable[b]
+	if e.opcode.Instruction == nil {
+		return nil, fmt.Errorf("unsupported opcode %00x", b)
 	}
+	c.opcode = b
 
 	if c.opts.tracing {
 		c.TraceStep = TraceStep{
 			PC:             c.PC,
-			Opcode:         opcode,
+			Opcode:         e.opcode,
 			OpcodeOperands: []byte{b},
 		}
 	}
-	return opcode, nil
+	return e, nil
 }
 
 // updatePC updates the program counter based on the instruction execution.
-func (c *CPU) updatePC(ins *Instruction, oldPC uint16, amount int) {
+func (c *CPU) updatePC(e *executor, oldPC uint16) {
 	// update PC only if the instruction execution did not change it
 	if oldPC == c.PC {
-		if ins.Name == Jmp.Name {
+		if e.jump || c.branchTaken {
 			return // endless loop detected
 		}
 
-		c.PC += uint16(amount)
+		c.PC += e.size
 		return
 	}
 
 	// page crossing is measured based on the start of the instruction that follows the
 	// current instruction
-	nextAddress := oldPC + uint16(amount)
+	nextAddress := oldPC + e.size
 	pageCrossed := c.PC&0xff00 != nextAddress&0xff00
-	if !pageCrossed {
-		return
-	}
-	if _, ok := BranchingInstructions[ins.Name]; !ok {
+	if !pageCrossed || !e.branching {
 		return
 	}
 
 	// account for a branch page crossing extra CPU cycle.
-	if ins.Name != Jmp.Name && ins.Name != Jsr.Name {
+	if e.branchPageCycle {
 		c.cycles++
 	}
 }
@@ -0,0 +1,52 @@
+// Package main downloads the prebuilt binaries of Klaus Dormann's 6502 test
+// suite into the testdata directory. The binaries are not part of this
+// repository due to their license, run go generate in the m6502 package to
+// fetch them.
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const baseURL = "https://raw.githubusercontent.com/Klaus2m5/6502_65C02_functional_tests/master/bin_files/"
+
+var files = []string{
+	"6502_functional_test.bin",
+	"6502_interrupt_test.bin",
+}
+
+func main() {
+	client := &http.Client{Timeout: time.Minute}
+	for _, name := range files {
+		if err := download(client, name, filepath.Join("testdata", name)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func download(client *http.Client, name, target string) error {
+	resp, err := client.Get(baseURL + name)
+	if err != nil {
+		return fmt.Errorf("downloading %s: %w", name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading %s: unexpected status %s", name, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", target, err)
+	}
+	return nil
+}
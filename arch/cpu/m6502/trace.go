@@ -0,0 +1,107 @@
+package m6502
+
+// AddressRange is an inclusive range of addresses.
+type AddressRange struct {
+	Start uint16
+	End   uint16
+}
+
+// Contains returns whether the address is inside the range.
+func (r AddressRange) Contains(address uint16) bool {
+	return address >= r.Start && address <= r.End
+}
+
+// TraceFilter limits the instructions that the pre execution hook is called
+// for, which allows tracing only parts of a program. All set conditions have
+// to match for an instruction to be traced, unset conditions match always.
+type TraceFilter struct {
+	IncludePC []AddressRange // instruction address has to be in one of the ranges
+	ExcludePC []AddressRange // instruction address must not be in any of the ranges
+
+	// IncludeMemory traces only instructions that access memory in one of
+	// the ranges, based on the addressing mode parameters.
+	IncludeMemory []AddressRange
+
+	// IncludeInstructions traces only the named instructions, the instruction
+	// category maps like BranchingInstructions can be used.
+	IncludeInstructions map[string]struct{}
+	ExcludeInstructions map[string]struct{}
+}
+
+// matches returns whether the instruction at the address passes the filter.
+func (f *TraceFilter) matches(address uint16, ins *Instruction, params []any) bool {
+	if len(f.IncludePC) > 0 && !inRanges(f.IncludePC, address) {
+		return false
+	}
+	if inRanges(f.ExcludePC, address) {
+		return false
+	}
+
+	if f.IncludeInstructions != nil {
+		if _, ok := f.IncludeInstructions[ins.Name]; !ok {
+			return false
+		}
+	}
+	if _, ok := f.ExcludeInstructions[ins.Name]; ok {
+		return false
+	}
+
+	if len(f.IncludeMemory) > 0 {
+		memoryAddress, ok := memoryAccessAddress(ins, params)
+		if !ok || !inRanges(f.IncludeMemory, memoryAddress) {
+			return false
+		}
+	}
+	return true
+}
+
+// memoryAccessAddress returns the memory address that the instruction accesses.
+// Jump and branch targets are not considered a memory access.
+func memoryAccessAddress(ins *Instruction, params []any) (uint16, bool) {
+	if _, ok := BranchingInstructions[ins.Name]; ok {
+		if len(params) == 0 {
+			return 0, false
+		}
+		if _, indirect := params[0].(Indirect); !indirect {
+			return 0, false
+		}
+	}
+	return paramAddress(params)
+}
+
+func inRanges(ranges []AddressRange, address uint16) bool {
+	for _, r := range ranges {
+		if r.Contains(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// paramAddress returns the memory address that the instruction parameters
+// reference, the index register offset is applied.
+func paramAddress(params []any) (uint16, bool) {
+	if len(params) == 0 {
+		return 0, false
+	}
+
+	var offset uint8
+	if len(params) > 1 {
+		if register, ok := params[1].(*uint8); ok {
+			offset = *register
+		}
+	}
+
+	switch address := params[0].(type) {
+	case Absolute:
+		return uint16(address) + uint16(offset), true
+	case ZeroPage:
+		return uint16(uint8(address) + offset), true
+	case Indirect:
+		return uint16(address), true
+	case IndirectResolved:
+		return uint16(address), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,79 @@
+package m6502
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/arch/nes"
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestTraceFilter(t *testing.T) {
+	t.Parallel()
+
+	code := []byte{
+		0xa2, 0x01, // ldx #$01
+		0x9d, 0x00, 0x02, // sta $0200,x
+		0x85, 0x10, // sta $10
+		0xe8,             // inx
+		0x4c, 0x00, 0x03, // jmp $0300
+	}
+
+	tests := []struct {
+		name     string
+		filter   TraceFilter
+		expected []string
+	}{
+		{
+			name:     "no filter",
+			expected: []string{"ldx", "sta", "sta", "inx", "jmp"},
+		},
+		{
+			name:     "pc range",
+			filter:   TraceFilter{IncludePC: []AddressRange{{Start: 0x8002, End: 0x8007}}},
+			expected: []string{"sta", "sta", "inx"},
+		},
+		{
+			name:     "excluded pc range",
+			filter:   TraceFilter{ExcludePC: []AddressRange{{Start: 0x8000, End: 0x8004}}},
+			expected: []string{"sta", "inx", "jmp"},
+		},
+		{
+			name:     "memory range",
+			filter:   TraceFilter{IncludeMemory: []AddressRange{{Start: 0x0201, End: 0x0201}}},
+			expected: []string{"sta"},
+		},
+		{
+			name:     "instructions",
+			filter:   TraceFilter{IncludeInstructions: BranchingInstructions},
+			expected: []string{"jmp"},
+		},
+		{
+			name:     "excluded instructions",
+			filter:   TraceFilter{ExcludeInstructions: map[string]struct{}{Sta.Name: {}}},
+			expected: []string{"ldx", "inx", "jmp"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			memory := NewMemory(&testMemory{})
+			memory.WriteWord(ResetAddress, nes.CodeBaseAddress)
+			for i, b := range code {
+				memory.Write(nes.CodeBaseAddress+uint16(i), b)
+			}
+
+			var traced []string
+			cpu := New(memory, WithTraceFilter(test.filter),
+				WithPreExecutionHook(func(_ *CPU, ins *Instruction, _ ...any) {
+					traced = append(traced, ins.Name)
+				}))
+
+			for range 5 {
+				assert.NoError(t, cpu.Step())
+			}
+			assert.Equal(t, test.expected, traced)
+		})
+	}
+}
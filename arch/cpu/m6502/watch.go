@@ -0,0 +1,49 @@
+package m6502
+
+// MemoryWrite is a write to a watched memory address.
+type MemoryWrite struct {
+	Address uint16
+	Old     uint8 // value of the previous write to the address, 0 if not written before
+	New     uint8
+}
+
+// writeWatcher records writes to the watched address ranges, multiple
+// writes to the same address are coalesced until the writes are delivered.
+// The old values are tracked from previous writes, the memory is not read
+// to avoid side effects of reading memory mapped registers.
+type writeWatcher struct {
+	BasicMemory
+
+	ranges  []AddressRange
+	handler func(writes []MemoryWrite)
+	last    map[uint16]uint8
+	pending []MemoryWrite
+}
+
+// Write writes the value to memory and records it if the address is watched.
+func (w *writeWatcher) Write(address uint16, value uint8) {
+	w.BasicMemory.Write(address, value)
+	if !inRanges(w.ranges, address) {
+		return
+	}
+
+	old := w.last[address]
+	w.last[address] = value
+
+	for i := range w.pending {
+		if w.pending[i].Address == address {
+			w.pending[i].New = value
+			return
+		}
+	}
+	w.pending = append(w.pending, MemoryWrite{Address: address, Old: old, New: value})
+}
+
+// deliver passes the pending writes to the handler.
+func (w *writeWatcher) deliver() {
+	if len(w.pending) == 0 {
+		return
+	}
+	w.handler(w.pending)
+	w.pending = w.pending[:0]
+}
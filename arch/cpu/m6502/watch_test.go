@@ -0,0 +1,66 @@
+package m6502
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/arch/nes"
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestWriteNotification(t *testing.T) {
+	t.Parallel()
+
+	var batches [][]MemoryWrite
+	handler := func(writes []MemoryWrite) {
+		batches = append(batches, append([]MemoryWrite(nil), writes...))
+	}
+
+	memory := NewMemory(&testMemory{})
+	memory.WriteWord(ResetAddress, nes.CodeBaseAddress)
+	code := []byte{
+		0xa9, 0x05, // lda #$05
+		0x8d, 0x00, 0x20, // sta $2000
+		0x8d, 0x00, 0x30, // sta $3000
+		0xee, 0x00, 0x20, // inc $2000
+	}
+	for i, b := range code {
+		memory.Write(nes.CodeBaseAddress+uint16(i), b)
+	}
+
+	cpu := New(memory, WithWriteNotification(handler, AddressRange{Start: 0x2000, End: 0x2007}))
+	for range 4 {
+		assert.NoError(t, cpu.Step())
+	}
+
+	assert.Equal(t, [][]MemoryWrite{
+		{{Address: 0x2000, Old: 0, New: 5}},
+		{{Address: 0x2000, Old: 5, New: 6}},
+	}, batches)
+}
+
+func TestWriteWatcherCoalesce(t *testing.T) {
+	t.Parallel()
+
+	var delivered []MemoryWrite
+	w := &writeWatcher{
+		BasicMemory: &testMemory{},
+		ranges:      []AddressRange{{Start: 0x10, End: 0x11}},
+		handler: func(writes []MemoryWrite) {
+			delivered = append(delivered, writes...)
+		},
+		last: map[uint16]uint8{},
+	}
+
+	w.Write(0x10, 1)
+	w.Write(0x11, 2)
+	w.Write(0x10, 3)
+	w.Write(0x12, 4)
+	w.deliver()
+
+	assert.Equal(t, []MemoryWrite{
+		{Address: 0x10, Old: 0, New: 3},
+		{Address: 0x11, Old: 0, New: 2},
+	}, delivered)
+	assert.Equal(t, 3, w.Read(0x10))
+	assert.Equal(t, 4, w.Read(0x12))
+}
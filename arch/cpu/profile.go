@@ -0,0 +1,247 @@
+package cpu
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// instruction categories of the cycles by category statistics.
+const (
+	CategoryBranch          = "branch"
+	CategoryMemoryRead      = "memory read"
+	CategoryMemoryWrite     = "memory write"
+	CategoryMemoryReadWrite = "memory read/write"
+	CategoryOther           = "other"
+)
+
+// Profile aggregates instruction execution statistics of any CPU core into
+// a unified report. The core or its execution loop passes every executed
+// instruction to Record, the m6502 core does this when created with the
+// WithProfile option. All methods are safe for concurrent use.
+type Profile struct {
+	mu sync.Mutex
+
+	instructions uint64
+	cycles       uint64
+	addresses    map[uint64]*AddressStats
+	opcodes      map[string]*InstructionStats // keyed by instruction name
+}
+
+// AddressStats contains the execution statistics of an address.
+type AddressStats struct {
+	Address     uint64 `json:"address"`
+	Executions  uint64 `json:"executions"`
+	Cycles      uint64 `json:"cycles"`
+	Instruction string `json:"instruction"`
+}
+
+// InstructionStats contains the execution statistics of an instruction.
+type InstructionStats struct {
+	Name       string `json:"name"`
+	Category   string `json:"category"`
+	Executions uint64 `json:"executions"`
+	Cycles     uint64 `json:"cycles"`
+}
+
+// CategoryStats contains the execution statistics of an instruction category.
+type CategoryStats struct {
+	Category   string `json:"category"`
+	Executions uint64 `json:"executions"`
+	Cycles     uint64 `json:"cycles"`
+}
+
+// Report is the unified statistics report of a profile.
+type Report struct {
+	Instructions uint64             `json:"instructions"`
+	Cycles       uint64             `json:"cycles"`
+	HotAddresses []AddressStats     `json:"hot_addresses"`
+	OpcodeMix    []InstructionStats `json:"opcode_mix"`
+	Categories   []CategoryStats    `json:"categories"`
+}
+
+// NewProfile returns a new empty profile.
+func NewProfile() *Profile {
+	return &Profile{
+		addresses: map[uint64]*AddressStats{},
+		opcodes:   map[string]*InstructionStats{},
+	}
+}
+
+// Record records the execution of an instruction at the given address that
+// took the given amount of cycles. The statistics are aggregated by the
+// instruction name, instructions like an official and unofficial nop that
+// share a name are counted together.
+func (p *Profile) Record(address uint64, ins Instruction, cycles uint64) {
+	p.RecordInfo(address, ins.Info(), cycles)
+}
+
+// RecordInfo records the execution of an instruction like Record, but takes
+// the instruction metadata, which allows CPU cores to pass cached metadata.
+func (p *Profile) RecordInfo(address uint64, info InstructionInfo, cycles uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.instructions++
+	p.cycles += cycles
+
+	opcode, ok := p.opcodes[info.Name]
+	if !ok {
+		opcode = &InstructionStats{
+			Name:     info.Name,
+			Category: Category(info),
+		}
+		p.opcodes[info.Name] = opcode
+	}
+	opcode.Executions++
+	opcode.Cycles += cycles
+
+	addr, ok := p.addresses[address]
+	if !ok {
+		addr = &AddressStats{Address: address}
+		p.addresses[address] = addr
+	}
+	addr.Executions++
+	addr.Cycles += cycles
+	addr.Instruction = opcode.Name
+}
+
+// Reset removes all recorded statistics.
+func (p *Profile) Reset() {
+	p.mu.Lock()
+	p.instructions = 0
+	p.cycles = 0
+	clear(p.addresses)
+	clear(p.opcodes)
+	p.mu.Unlock()
+}
+
+// Report returns the report of the recorded statistics. It contains the
+// hotCount addresses with the most used cycles, a negative value includes
+// all addresses. All lists are sorted by cycles in descending order.
+func (p *Profile) Report(hotCount int) Report {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	report := Report{
+		Instructions: p.instructions,
+		Cycles:       p.cycles,
+		HotAddresses: make([]AddressStats, 0, len(p.addresses)),
+		OpcodeMix:    make([]InstructionStats, 0, len(p.opcodes)),
+	}
+
+	for _, addr := range p.addresses {
+		report.HotAddresses = append(report.HotAddresses, *addr)
+	}
+	slices.SortFunc(report.HotAddresses, func(a, b AddressStats) int {
+		return cmp.Or(cmp.Compare(b.Cycles, a.Cycles), cmp.Compare(a.Address, b.Address))
+	})
+	if hotCount >= 0 && hotCount < len(report.HotAddresses) {
+		report.HotAddresses = report.HotAddresses[:hotCount]
+	}
+
+	categories := map[string]*CategoryStats{}
+	for _, opcode := range p.opcodes {
+		report.OpcodeMix = append(report.OpcodeMix, *opcode)
+
+		category, ok := categories[opcode.Category]
+		if !ok {
+			category = &CategoryStats{Category: opcode.Category}
+			categories[opcode.Category] = category
+		}
+		category.Executions += opcode.Executions
+		category.Cycles += opcode.Cycles
+	}
+	slices.SortFunc(report.OpcodeMix, func(a, b InstructionStats) int {
+		return cmp.Or(cmp.Compare(b.Cycles, a.Cycles), cmp.Compare(a.Name, b.Name))
+	})
+
+	for _, category := range categories {
+		report.Categories = append(report.Categories, *category)
+	}
+	slices.SortFunc(report.Categories, func(a, b CategoryStats) int {
+		return cmp.Or(cmp.Compare(b.Cycles, a.Cycles), cmp.Compare(a.Category, b.Category))
+	})
+
+	return report
+}
+
+// Category returns the statistics category of an instruction.
+func Category(info InstructionInfo) string {
+	switch {
+	case info.Branching:
+		return CategoryBranch
+	case info.Memory == MemoryReadWrite:
+		return CategoryMemoryReadWrite
+	case info.Memory == MemoryWrite:
+		return CategoryMemoryWrite
+	case info.Memory == MemoryRead:
+		return CategoryMemoryRead
+	default:
+		return CategoryOther
+	}
+}
+
+// WriteJSON writes the report as indented JSON to the writer.
+func (r Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+	return nil
+}
+
+// WriteText writes the report as human readable text to the writer.
+func (r Report) WriteText(w io.Writer) error {
+	ew := &errWriter{w: w}
+	ew.printf("instructions: %d\ncycles: %d\n", r.Instructions, r.Cycles)
+
+	ew.printf("\nhot addresses:\n")
+	for _, addr := range r.HotAddresses {
+		ew.printf("  0x%04X  %-6s %10d executions %10d cycles %6.2f%%\n",
+			addr.Address, addr.Instruction, addr.Executions, addr.Cycles, percent(addr.Cycles, r.Cycles))
+	}
+
+	ew.printf("\nopcode mix:\n")
+	for _, opcode := range r.OpcodeMix {
+		ew.printf("  %-6s %10d executions %10d cycles %6.2f%%\n",
+			opcode.Name, opcode.Executions, opcode.Cycles, percent(opcode.Cycles, r.Cycles))
+	}
+
+	ew.printf("\ncycles by category:\n")
+	for _, category := range r.Categories {
+		ew.printf("  %-17s %10d executions %10d cycles %6.2f%%\n",
+			category.Category, category.Executions, category.Cycles, percent(category.Cycles, r.Cycles))
+	}
+
+	if ew.err != nil {
+		return fmt.Errorf("writing report: %w", ew.err)
+	}
+	return nil
+}
+
+// percent returns the share of the value in the total in percent.
+func percent(value, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) * 100 / float64(total)
+}
+
+// errWriter keeps the first write error, which allows writing multiple
+// lines without checking every write.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}
@@ -0,0 +1,79 @@
+package cpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+type testInstruction InstructionInfo
+
+func (ins *testInstruction) Info() InstructionInfo {
+	return InstructionInfo(*ins)
+}
+
+func TestProfile(t *testing.T) {
+	lda := &testInstruction{Name: "lda", Memory: MemoryRead}
+	sta := &testInstruction{Name: "sta", Memory: MemoryWrite}
+	bne := &testInstruction{Name: "bne", Branching: true}
+
+	p := NewProfile()
+	for range 10 {
+		p.Record(0x8000, lda, 4)
+		p.Record(0x8003, sta, 5)
+		p.Record(0x8006, bne, 3)
+	}
+	p.Record(0x8008, lda, 2)
+
+	report := p.Report(2)
+	assert.Equal(t, 31, report.Instructions)
+	assert.Equal(t, 122, report.Cycles)
+	assert.Equal(t, []AddressStats{
+		{Address: 0x8003, Executions: 10, Cycles: 50, Instruction: "sta"},
+		{Address: 0x8000, Executions: 10, Cycles: 40, Instruction: "lda"},
+	}, report.HotAddresses)
+	assert.Equal(t, []InstructionStats{
+		{Name: "sta", Category: CategoryMemoryWrite, Executions: 10, Cycles: 50},
+		{Name: "lda", Category: CategoryMemoryRead, Executions: 11, Cycles: 42},
+		{Name: "bne", Category: CategoryBranch, Executions: 10, Cycles: 30},
+	}, report.OpcodeMix)
+	assert.Len(t, report.Categories, 3)
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.WriteJSON(&buf))
+	var decoded Report
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report, decoded)
+
+	buf.Reset()
+	assert.NoError(t, report.WriteText(&buf))
+	assert.True(t, strings.Contains(buf.String(), "0x8003  sta"))
+	assert.True(t, strings.Contains(buf.String(), "memory write"))
+
+	p.Reset()
+	assert.Equal(t, 0, p.Report(-1).Instructions)
+}
+
+// valueInstruction is a non-comparable instruction implementation.
+type valueInstruction struct {
+	info  InstructionInfo
+	bytes []byte
+}
+
+func (ins valueInstruction) Info() InstructionInfo {
+	return ins.info
+}
+
+func TestProfileAggregatesByName(t *testing.T) {
+	p := NewProfile()
+	p.Record(0x8000, &testInstruction{Name: "nop"}, 2)
+	p.Record(0x8001, &testInstruction{Name: "nop", Unofficial: true}, 2)
+	p.Record(0x8002, valueInstruction{info: InstructionInfo{Name: "nop"}}, 2)
+
+	assert.Equal(t, []InstructionStats{
+		{Name: "nop", Category: CategoryOther, Executions: 3, Cycles: 6},
+	}, p.Report(0).OpcodeMix)
+}
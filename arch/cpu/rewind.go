@@ -0,0 +1,195 @@
+package cpu
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Snapshotter is implemented by systems whose CPU and memory state can be
+// saved and restored, it is used by the rewind buffer.
+type Snapshotter interface {
+	SaveState() ([]byte, error)
+	LoadState(data []byte) error
+}
+
+// Rewind keeps snapshots of a system in a ring buffer to allow rewinding
+// the emulation. A snapshot is taken every interval frames. Only the newest
+// snapshot is stored completely, older snapshots are stored as run length
+// encoded differences to the following snapshot, which keeps the memory
+// usage low for states that change little between snapshots.
+// All methods are safe for concurrent use.
+type Rewind struct {
+	mu sync.Mutex
+
+	system   Snapshotter
+	interval uint64
+	capacity int
+
+	frame  uint64     // current frame number
+	latest snapshot   // newest snapshot, stored completely
+	deltas []snapshot // older snapshots, ordered from oldest to newest
+}
+
+// snapshot is a state of the system at a frame, for deltas the data
+// contains the encoded difference to the next newer snapshot.
+type snapshot struct {
+	frame uint64
+	data  []byte
+	full  bool // data is not delta encoded as the state size changed
+}
+
+// ErrNoSnapshot is returned if no snapshot is old enough to rewind to.
+var ErrNoSnapshot = errors.New("no snapshot available")
+
+// NewRewind returns a new rewind buffer for the system that keeps up to
+// capacity snapshots and takes a snapshot every interval frames.
+func NewRewind(system Snapshotter, capacity, interval int) *Rewind {
+	return &Rewind{
+		system:   system,
+		interval: uint64(max(interval, 1)),
+		capacity: max(capacity, 1),
+	}
+}
+
+// Frame has to be called once per emulated frame, it takes a snapshot if
+// the snapshot interval has been reached.
+func (r *Rewind) Frame() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	frame := r.frame
+	r.frame++
+	if frame%r.interval != 0 {
+		return nil
+	}
+
+	data, err := r.system.SaveState()
+	if err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+
+	if r.latest.data != nil {
+		delta := snapshot{frame: r.latest.frame}
+		if len(r.latest.data) == len(data) {
+			delta.data = encodeDelta(r.latest.data, data)
+		} else {
+			delta.data = r.latest.data
+			delta.full = true
+		}
+		r.push(delta)
+	}
+	r.latest = snapshot{frame: frame, data: data}
+	return nil
+}
+
+// Snapshots returns the number of stored snapshots.
+func (r *Rewind) Snapshots() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.latest.data == nil {
+		return 0
+	}
+	return len(r.deltas) + 1
+}
+
+// Rewind restores the newest snapshot that was taken at least the given
+// amount of frames ago, snapshots that are newer get discarded. It returns
+// the amount of frames that the system was rewound.
+func (r *Rewind) Rewind(frames int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.latest.data == nil || uint64(frames) > r.frame {
+		return 0, ErrNoSnapshot
+	}
+	target := r.frame - uint64(frames)
+
+	state := r.latest
+	count := len(r.deltas)
+	for state.frame > target {
+		if count == 0 {
+			return 0, ErrNoSnapshot
+		}
+		count--
+		delta := r.deltas[count]
+
+		data := delta.data
+		if !delta.full {
+			data = decodeDelta(state.data, delta.data)
+		}
+		state = snapshot{frame: delta.frame, data: data}
+	}
+
+	if err := r.system.LoadState(state.data); err != nil {
+		return 0, fmt.Errorf("loading state: %w", err)
+	}
+
+	rewound := int(r.frame - state.frame)
+	r.deltas = r.deltas[:count]
+	r.latest = state
+	r.frame = state.frame + 1
+	return rewound, nil
+}
+
+// Reset removes all snapshots.
+func (r *Rewind) Reset() {
+	r.mu.Lock()
+	r.frame = 0
+	r.latest = snapshot{}
+	r.deltas = nil
+	r.mu.Unlock()
+}
+
+// push adds a delta, dropping the oldest snapshot if the capacity is reached.
+func (r *Rewind) push(delta snapshot) {
+	r.deltas = append(r.deltas, delta)
+	if len(r.deltas) >= r.capacity {
+		r.deltas = slices.Delete(r.deltas, 0, len(r.deltas)-r.capacity+1)
+	}
+}
+
+// encodeDelta encodes the difference of the old state to the new state as
+// sequence of unchanged byte run lengths followed by the changed bytes.
+// Both states have to be of the same size.
+func encodeDelta(old, data []byte) []byte {
+	var encoded []byte
+	for i := 0; i < len(data); {
+		unchanged := i
+		for unchanged < len(data) && old[unchanged] == data[unchanged] {
+			unchanged++
+		}
+		changed := unchanged
+		for changed < len(data) && old[changed] != data[changed] {
+			changed++
+		}
+
+		encoded = binary.AppendUvarint(encoded, uint64(unchanged-i))
+		encoded = binary.AppendUvarint(encoded, uint64(changed-unchanged))
+		encoded = append(encoded, old[unchanged:changed]...)
+		i = changed
+	}
+	return encoded
+}
+
+// decodeDelta returns the old state that the delta was encoded from.
+func decodeDelta(data, delta []byte) []byte {
+	old := make([]byte, len(data))
+	copy(old, data)
+
+	offset := 0
+	for len(delta) > 0 {
+		unchanged, n := binary.Uvarint(delta)
+		delta = delta[n:]
+		changed, n := binary.Uvarint(delta)
+		delta = delta[n:]
+
+		offset += int(unchanged)
+		offset += copy(old[offset:], delta[:changed])
+		delta = delta[changed:]
+	}
+	return old
+}
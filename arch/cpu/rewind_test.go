@@ -0,0 +1,71 @@
+package cpu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+type testSystem struct {
+	memory []byte
+}
+
+func (s *testSystem) SaveState() ([]byte, error) {
+	return append([]byte(nil), s.memory...), nil
+}
+
+func (s *testSystem) LoadState(data []byte) error {
+	s.memory = append(s.memory[:0], data...)
+	return nil
+}
+
+func TestRewind(t *testing.T) {
+	system := &testSystem{memory: make([]byte, 64)}
+	r := NewRewind(system, 3, 2)
+
+	// frame 0, 2, 4 and 6 get snapshots, only the last 3 are kept
+	for frame := range 8 {
+		system.memory[frame] = byte(frame + 1)
+		assert.NoError(t, r.Frame())
+	}
+	assert.Equal(t, 3, r.Snapshots())
+
+	rewound, err := r.Rewind(3) // restores the snapshot of frame 4
+	assert.NoError(t, err)
+	assert.Equal(t, 4, rewound)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, system.memory[:5])
+	assert.Equal(t, 0, system.memory[5])
+	assert.Equal(t, 2, r.Snapshots())
+
+	_, err = r.Rewind(4)
+	assert.True(t, errors.Is(err, ErrNoSnapshot))
+
+	rewound, err = r.Rewind(2) // restores the snapshot of frame 2
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rewound)
+	assert.Equal(t, []byte{1, 2, 3, 0}, system.memory[:4])
+}
+
+func TestRewindSizeChange(t *testing.T) {
+	system := &testSystem{memory: []byte{1, 2}}
+	r := NewRewind(system, 4, 1)
+	assert.NoError(t, r.Frame())
+	system.memory = append(system.memory, 3)
+	assert.NoError(t, r.Frame())
+
+	_, err := r.Rewind(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, system.memory)
+
+	r.Reset()
+	assert.Equal(t, 0, r.Snapshots())
+}
+
+func TestDeltaEncoding(t *testing.T) {
+	old := []byte{0, 1, 2, 3, 4, 5, 6, 7}
+	data := []byte{0, 9, 9, 3, 4, 5, 6, 8}
+	delta := encodeDelta(old, data)
+	assert.Equal(t, []byte{1, 2, 1, 2, 4, 1, 7}, delta)
+	assert.Equal(t, old, decodeDelta(data, delta))
+}
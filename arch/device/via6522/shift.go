@@ -0,0 +1,73 @@
+package via6522
+
+// shift register modes of the auxiliary control register bits 2-4.
+const (
+	shiftDisabled       = 0b000
+	shiftInT2           = 0b001
+	shiftInPhi2         = 0b010
+	shiftInExternal     = 0b011
+	shiftOutFreeRunning = 0b100
+	shiftOutT2          = 0b101
+	shiftOutPhi2        = 0b110
+	shiftOutExternal    = 0b111
+)
+
+func (v *VIA) shiftMode() uint8 {
+	return (v.acr >> 2) & 0b111
+}
+
+// startShift starts a new shift sequence of 8 bits, it is triggered by
+// reading or writing the shift register.
+func (v *VIA) startShift() {
+	v.clearInterrupt(InterruptShift)
+	v.shiftCount = 0
+	v.shiftTimer = uint16(v.t2LatchLow) + 2
+	v.shiftActive = v.shiftMode() != shiftDisabled
+}
+
+// tickShift shifts bits in the modes that are clocked by φ2 or timer 2.
+func (v *VIA) tickShift() {
+	mode := v.shiftMode()
+	switch mode {
+	case shiftInPhi2, shiftOutPhi2:
+		v.shift()
+
+	case shiftInT2, shiftOutT2, shiftOutFreeRunning:
+		v.shiftTimer--
+		if v.shiftTimer == 0 {
+			v.shiftTimer = uint16(v.t2LatchLow) + 2
+			v.shift()
+		}
+	}
+}
+
+// shift shifts a bit in or out of the shift register. Shifting stops after
+// 8 bits and sets the shift interrupt flag, except for the free running mode.
+func (v *VIA) shift() {
+	mode := v.shiftMode()
+	if !v.shiftActive && mode != shiftOutFreeRunning {
+		return
+	}
+
+	if mode&0b100 != 0 { // shift out, the output bit is rotated back in
+		bit := v.sr&0x80 != 0
+		v.sr = v.sr<<1 | v.sr>>7
+		if v.ShiftOut != nil {
+			v.ShiftOut(bit)
+		}
+	} else {
+		v.sr <<= 1
+		if v.ShiftIn != nil && v.ShiftIn() {
+			v.sr |= 1
+		}
+	}
+
+	if mode == shiftOutFreeRunning {
+		return
+	}
+	v.shiftCount++
+	if v.shiftCount == 8 {
+		v.shiftActive = false
+		v.setInterrupt(InterruptShift)
+	}
+}
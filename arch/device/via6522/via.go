@@ -0,0 +1,360 @@
+// Package via6522 provides an emulation of the MOS Technology 6522 Versatile
+// Interface Adapter, a timer and IO device used with 6502 based systems.
+// It implements the peripheral interface of the bus package.
+package via6522
+
+import "github.com/retroenv/retrogolib/arch/bus"
+
+var _ bus.Peripheral = &VIA{}
+
+// register offsets.
+const (
+	regORB   = 0x0 // output/input register B
+	regORA   = 0x1 // output/input register A
+	regDDRB  = 0x2 // data direction register B
+	regDDRA  = 0x3 // data direction register A
+	regT1CL  = 0x4 // timer 1 counter low
+	regT1CH  = 0x5 // timer 1 counter high
+	regT1LL  = 0x6 // timer 1 latch low
+	regT1LH  = 0x7 // timer 1 latch high
+	regT2CL  = 0x8 // timer 2 counter low
+	regT2CH  = 0x9 // timer 2 counter high
+	regSR    = 0xA // shift register
+	regACR   = 0xB // auxiliary control register
+	regPCR   = 0xC // peripheral control register
+	regIFR   = 0xD // interrupt flag register
+	regIER   = 0xE // interrupt enable register
+	regORANH = 0xF // output/input register A without handshake
+)
+
+// Interrupt flags of the interrupt flag and enable registers.
+const (
+	InterruptCA2   = 1 << 0
+	InterruptCA1   = 1 << 1
+	InterruptShift = 1 << 2
+	InterruptCB2   = 1 << 3
+	InterruptCB1   = 1 << 4
+	InterruptT2    = 1 << 5
+	InterruptT1    = 1 << 6
+
+	interruptAny = 1 << 7
+)
+
+// auxiliary control register bits.
+const (
+	acrT2PulseCounting = 1 << 5
+	acrT1Continuous    = 1 << 6
+	acrT1PB7Output     = 1 << 7
+)
+
+// Port defines the connection of an IO port to the pins of the system.
+// Nil functions read all pins as high and ignore writes.
+type Port struct {
+	Read  func() uint8      // returns the state of the input pins
+	Write func(value uint8) // receives the state of the output pins, input pins are set high
+}
+
+// VIA is a 6522 Versatile Interface Adapter.
+type VIA struct {
+	PortA Port
+	PortB Port
+
+	// ShiftOut receives the bits that the shift register outputs on CB2.
+	ShiftOut func(bit bool)
+	// ShiftIn returns the CB2 input bit for the shift register.
+	ShiftIn func() bool
+
+	ora, orb   uint8
+	ddra, ddrb uint8
+
+	t1Counter uint16
+	t1Latch   uint16
+	t1Armed   bool // interrupt is generated on next timeout
+	t1Reload  bool // counter is reloaded from the latch in the next cycle
+	pb7       bool // PB7 output level controlled by timer 1
+
+	t2Counter  uint16
+	t2LatchLow uint8
+	t2Armed    bool
+
+	sr          uint8
+	shiftCount  int    // number of bits shifted since the start
+	shiftTimer  uint16 // cycles until the next shift for T2 controlled shifting
+	shiftActive bool
+
+	acr, pcr uint8
+	ifr, ier uint8
+
+	ca1, cb1 bool // current levels of the control lines
+}
+
+// New returns a new VIA in reset state.
+func New() *VIA {
+	v := &VIA{}
+	v.Reset()
+	return v
+}
+
+// Reset resets all registers, the timers and shift register are disabled.
+func (v *VIA) Reset() {
+	portA, portB := v.PortA, v.PortB
+	shiftOut, shiftIn := v.ShiftOut, v.ShiftIn
+	*v = VIA{
+		PortA:     portA,
+		PortB:     portB,
+		ShiftOut:  shiftOut,
+		ShiftIn:   shiftIn,
+		t1Counter: 0xFFFF,
+		t1Latch:   0xFFFF,
+		t2Counter: 0xFFFF,
+		pb7:       true,
+	}
+}
+
+// IRQ returns whether the VIA requests an interrupt.
+func (v *VIA) IRQ() bool {
+	return v.ifr&v.ier&^interruptAny != 0
+}
+
+// Read reads a register, the address is relative to the base address of the device.
+func (v *VIA) Read(address uint16) uint8 {
+	switch address & 0x0F {
+	case regORB:
+		v.clearInterrupt(InterruptCB1 | InterruptCB2)
+		return v.readPortB()
+	case regORA:
+		v.clearInterrupt(InterruptCA1 | InterruptCA2)
+		return readPort(v.PortA, v.ora, v.ddra)
+	case regORANH:
+		return readPort(v.PortA, v.ora, v.ddra)
+	case regDDRB:
+		return v.ddrb
+	case regDDRA:
+		return v.ddra
+	case regT1CL:
+		v.clearInterrupt(InterruptT1)
+		return uint8(v.t1Counter)
+	case regT1CH:
+		return uint8(v.t1Counter >> 8)
+	case regT1LL:
+		return uint8(v.t1Latch)
+	case regT1LH:
+		return uint8(v.t1Latch >> 8)
+	case regT2CL:
+		v.clearInterrupt(InterruptT2)
+		return uint8(v.t2Counter)
+	case regT2CH:
+		return uint8(v.t2Counter >> 8)
+	case regSR:
+		v.startShift()
+		return v.sr
+	case regACR:
+		return v.acr
+	case regPCR:
+		return v.pcr
+	case regIFR:
+		return v.interruptFlags()
+	default: // regIER
+		return v.ier | interruptAny
+	}
+}
+
+// Write writes a register, the address is relative to the base address of the device.
+func (v *VIA) Write(address uint16, value uint8) {
+	switch address & 0x0F {
+	case regORB:
+		v.clearInterrupt(InterruptCB1 | InterruptCB2)
+		v.orb = value
+		v.writePortB()
+	case regORA:
+		v.clearInterrupt(InterruptCA1 | InterruptCA2)
+		v.ora = value
+		writePort(v.PortA, v.ora, v.ddra)
+	case regORANH:
+		v.ora = value
+		writePort(v.PortA, v.ora, v.ddra)
+	case regDDRB:
+		v.ddrb = value
+		v.writePortB()
+	case regDDRA:
+		v.ddra = value
+		writePort(v.PortA, v.ora, v.ddra)
+	case regT1CL, regT1LL:
+		v.t1Latch = v.t1Latch&0xFF00 | uint16(value)
+	case regT1CH:
+		v.t1Latch = v.t1Latch&0x00FF | uint16(value)<<8
+		v.t1Counter = v.t1Latch
+		v.t1Armed = true
+		v.t1Reload = false
+		v.clearInterrupt(InterruptT1)
+		if v.acr&acrT1PB7Output != 0 {
+			v.pb7 = false
+			v.writePortB()
+		}
+	case regT1LH:
+		v.t1Latch = v.t1Latch&0x00FF | uint16(value)<<8
+		v.clearInterrupt(InterruptT1)
+	case regT2CL:
+		v.t2LatchLow = value
+	case regT2CH:
+		v.t2Counter = uint16(value)<<8 | uint16(v.t2LatchLow)
+		v.t2Armed = true
+		v.clearInterrupt(InterruptT2)
+	case regSR:
+		v.sr = value
+		v.startShift()
+	case regACR:
+		v.acr = value
+		v.writePortB()
+	case regPCR:
+		v.pcr = value
+	case regIFR:
+		v.clearInterrupt(value)
+	default: // regIER
+		if value&interruptAny != 0 {
+			v.ier |= value &^ interruptAny
+		} else {
+			v.ier &^= value
+		}
+	}
+}
+
+// Tick advances the timers and shift register by the given amount of cycles.
+func (v *VIA) Tick(cycles uint64) {
+	for range cycles {
+		v.tickTimer1()
+		if v.acr&acrT2PulseCounting == 0 {
+			v.decrementTimer2()
+		}
+		v.tickShift()
+	}
+}
+
+// PulsePB6 counts a pulse on PB6, which decrements timer 2 in pulse counting mode.
+func (v *VIA) PulsePB6() {
+	if v.acr&acrT2PulseCounting != 0 {
+		v.decrementTimer2()
+	}
+}
+
+// SetCA1 sets the level of the CA1 control line. The CA1 interrupt flag is set
+// on the active edge that is selected in the peripheral control register.
+func (v *VIA) SetCA1(level bool) {
+	if v.ca1 != level && level == (v.pcr&0x01 != 0) {
+		v.setInterrupt(InterruptCA1)
+	}
+	v.ca1 = level
+}
+
+// SetCB1 sets the level of the CB1 control line. The CB1 interrupt flag is set
+// on the active edge that is selected in the peripheral control register.
+// In the shift register modes with external clock, a rising edge shifts a bit.
+func (v *VIA) SetCB1(level bool) {
+	if v.cb1 != level && level == (v.pcr&0x10 != 0) {
+		v.setInterrupt(InterruptCB1)
+	}
+	if !v.cb1 && level && v.shiftMode()&0b011 == 0b011 {
+		v.shift()
+	}
+	v.cb1 = level
+}
+
+func (v *VIA) tickTimer1() {
+	if v.t1Reload {
+		v.t1Reload = false
+		v.t1Counter = v.t1Latch
+		return
+	}
+
+	if v.t1Counter != 0 {
+		v.t1Counter--
+		return
+	}
+
+	// timer underflow
+	v.t1Counter = 0xFFFF
+	continuous := v.acr&acrT1Continuous != 0
+	if continuous {
+		v.t1Reload = true
+	}
+	if !v.t1Armed {
+		return
+	}
+
+	v.setInterrupt(InterruptT1)
+	if !continuous {
+		v.t1Armed = false
+	}
+	if v.acr&acrT1PB7Output != 0 {
+		if continuous {
+			v.pb7 = !v.pb7
+		} else {
+			v.pb7 = true
+		}
+		v.writePortB()
+	}
+}
+
+func (v *VIA) decrementTimer2() {
+	if v.t2Counter == 0 && v.t2Armed {
+		v.t2Armed = false
+		v.setInterrupt(InterruptT2)
+	}
+	v.t2Counter--
+}
+
+func (v *VIA) setInterrupt(flags uint8) {
+	v.ifr |= flags
+}
+
+func (v *VIA) clearInterrupt(flags uint8) {
+	v.ifr &^= flags &^ interruptAny
+}
+
+// interruptFlags returns the interrupt flag register, bit 7 is set if any
+// enabled interrupt is active.
+func (v *VIA) interruptFlags() uint8 {
+	if v.IRQ() {
+		return v.ifr | interruptAny
+	}
+	return v.ifr
+}
+
+func (v *VIA) readPortB() uint8 {
+	value := readPort(v.PortB, v.orb, v.ddrb)
+	if v.acr&acrT1PB7Output != 0 {
+		value &^= 0x80
+		if v.pb7 {
+			value |= 0x80
+		}
+	}
+	return value
+}
+
+func (v *VIA) writePortB() {
+	value, ddr := v.orb, v.ddrb
+	if v.acr&acrT1PB7Output != 0 {
+		ddr |= 0x80
+		value &^= 0x80
+		if v.pb7 {
+			value |= 0x80
+		}
+	}
+	writePort(v.PortB, value, ddr)
+}
+
+// readPort returns the output register value for output pins and the pin
+// state for input pins.
+func readPort(port Port, output, ddr uint8) uint8 {
+	pins := uint8(0xFF)
+	if port.Read != nil {
+		pins = port.Read()
+	}
+	return output&ddr | pins&^ddr
+}
+
+func writePort(port Port, output, ddr uint8) {
+	if port.Write != nil {
+		port.Write(output&ddr | ^ddr)
+	}
+}
@@ -0,0 +1,141 @@
+package via6522
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestTimer1OneShot(t *testing.T) {
+	v := New()
+	v.Write(regIER, interruptAny|InterruptT1)
+	v.Write(regT1CL, 0x10)
+	v.Write(regT1CH, 0x00)
+	assert.Equal(t, 0x10, v.Read(regT1CL))
+
+	v.Tick(0x10)
+	assert.False(t, v.IRQ())
+	v.Tick(1)
+	assert.True(t, v.IRQ())
+	assert.Equal(t, interruptAny|InterruptT1, v.Read(regIFR))
+
+	// reading the low counter clears the flag
+	v.Read(regT1CL)
+	assert.False(t, v.IRQ())
+
+	// no interrupt in one shot mode after the first timeout
+	v.Tick(0x20000)
+	assert.False(t, v.IRQ())
+}
+
+func TestTimer1Continuous(t *testing.T) {
+	var pb uint8
+	v := New()
+	v.PortB.Write = func(value uint8) {
+		pb = value
+	}
+	v.Write(regACR, acrT1Continuous|acrT1PB7Output)
+	v.Write(regT1CL, 0x04)
+	v.Write(regT1CH, 0x00)
+	assert.Equal(t, 0x7F, pb)
+
+	// first timeout after latch + 1 cycles, then every latch + 2 cycles
+	for i := range 3 {
+		v.Tick(uint64(4 + min(i, 1)))
+		assert.Equal(t, 0, v.Read(regIFR)&InterruptT1)
+		v.Tick(1)
+		assert.Equal(t, InterruptT1, v.Read(regIFR)&InterruptT1, "timeout %d", i)
+		v.Write(regIFR, InterruptT1)
+	}
+	// PB7 toggled 3 times
+	assert.Equal(t, 0xFF, pb)
+}
+
+func TestTimer2(t *testing.T) {
+	v := New()
+	v.Write(regIER, interruptAny|InterruptT2)
+	v.Write(regT2CL, 0x02)
+	v.Write(regT2CH, 0x00)
+	v.Tick(3)
+	assert.True(t, v.IRQ())
+	v.Read(regT2CL)
+	assert.False(t, v.IRQ())
+
+	v.Write(regACR, acrT2PulseCounting)
+	v.Write(regT2CH, 0x00)
+	v.Tick(100)
+	assert.False(t, v.IRQ())
+	for range 3 {
+		v.PulsePB6()
+	}
+	assert.True(t, v.IRQ())
+}
+
+func TestInterruptEnable(t *testing.T) {
+	v := New()
+	v.Write(regIER, interruptAny|InterruptCA1|InterruptT1)
+	assert.Equal(t, interruptAny|InterruptCA1|InterruptT1, v.Read(regIER))
+	v.Write(regIER, InterruptT1)
+	assert.Equal(t, interruptAny|InterruptCA1, v.Read(regIER))
+
+	// negative edge is active by default
+	v.SetCA1(true)
+	assert.False(t, v.IRQ())
+	v.SetCA1(false)
+	assert.True(t, v.IRQ())
+
+	// reading port A clears the flag
+	v.Read(regORA)
+	assert.False(t, v.IRQ())
+}
+
+func TestPorts(t *testing.T) {
+	var written uint8
+	v := New()
+	v.PortA.Read = func() uint8 {
+		return 0x0F
+	}
+	v.PortA.Write = func(value uint8) {
+		written = value
+	}
+
+	v.Write(regDDRA, 0xF0)
+	v.Write(regORA, 0xA5)
+	assert.Equal(t, 0xAF, written)
+	assert.Equal(t, 0xAF, v.Read(regORA))
+	assert.Equal(t, 0xAF, v.Read(regORANH))
+
+	// registers are mirrored
+	assert.Equal(t, 0xF0, v.Read(0x13))
+}
+
+func TestShiftRegister(t *testing.T) {
+	var bits []bool
+	v := New()
+	v.ShiftOut = func(bit bool) {
+		bits = append(bits, bit)
+	}
+	v.Write(regIER, interruptAny|InterruptShift)
+	v.Write(regACR, shiftOutPhi2<<2)
+	v.Write(regSR, 0b1010_0011)
+
+	v.Tick(7)
+	assert.False(t, v.IRQ())
+	v.Tick(10)
+	assert.True(t, v.IRQ())
+	assert.Equal(t, []bool{true, false, true, false, false, false, true, true}, bits)
+	assert.Equal(t, 0b1010_0011, v.Read(regSR))
+	assert.False(t, v.IRQ())
+
+	v.ShiftIn = func() bool {
+		return true
+	}
+	v.Write(regACR, shiftInExternal<<2)
+	v.Read(regSR)
+	for range 8 {
+		v.SetCB1(true)
+		v.SetCB1(false)
+	}
+	assert.Equal(t, 0xFF, v.sr)
+	assert.True(t, v.IRQ())
+}
@@ -0,0 +1,144 @@
+// Package cartridge provides Game Boy cartridge loading and memory bank
+// controller emulation.
+package cartridge
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Options contains options for the cartridge.
+type Options struct {
+	ramSaver func(ram []byte)
+}
+
+// Option defines a cartridge parameter.
+type Option func(*Options)
+
+// WithRAMSaver sets a function that gets called with a copy of the content
+// of the battery backed RAM when a program disables the RAM after writing to
+// it, which is the point where games consider their save data written, and
+// when SaveRAM is called.
+func WithRAMSaver(saver func(ram []byte)) Option {
+	return func(options *Options) {
+		options.ramSaver = saver
+	}
+}
+
+// Cartridge contains a Game Boy cartridge content and the state of its
+// memory bank controller.
+type Cartridge struct {
+	Header Header
+	ROM    []byte
+	RAM    []byte
+
+	opts     Options
+	mbc      mbc
+	ramDirty bool
+}
+
+// New returns a new cartridge for the ROM content.
+func New(rom []byte, options ...Option) (*Cartridge, error) {
+	header, err := ParseHeader(rom)
+	if err != nil {
+		return nil, fmt.Errorf("parsing header: %w", err)
+	}
+	if len(rom) < header.ROMBanks*romBankSize {
+		return nil, fmt.Errorf("ROM size %d is smaller than the %d banks of the header", len(rom), header.ROMBanks)
+	}
+
+	c := &Cartridge{
+		Header: header,
+		ROM:    rom,
+		RAM:    make([]byte, header.RAMSize),
+	}
+	for _, option := range options {
+		option(&c.opts)
+	}
+
+	switch header.MBC {
+	case ROMOnly:
+		c.mbc = &romOnly{}
+	case MBC1:
+		c.mbc = &mbc1{bank1: 1}
+	case MBC3:
+		c.mbc = &mbc3{romBank: 1}
+	case MBC5:
+		c.mbc = &mbc5{romBank: 1}
+	default:
+		return nil, fmt.Errorf("unsupported memory bank controller %s (type %02x)", header.MBC, header.Type)
+	}
+	return c, nil
+}
+
+// LoadFile loads a .gb or .gbc file.
+func LoadFile(reader io.Reader, options ...Option) (*Cartridge, error) {
+	rom, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading ROM: %w", err)
+	}
+	return New(rom, options...)
+}
+
+// LoadRAM restores the content of the cartridge RAM, for example from a
+// save file of a battery backed cartridge.
+func (c *Cartridge) LoadRAM(data []byte) error {
+	if len(data) != len(c.RAM) {
+		return errors.New("RAM data size mismatch")
+	}
+	copy(c.RAM, data)
+	return nil
+}
+
+// Read returns the value at the CPU address, the cartridge decodes the ROM
+// area $0000-$7FFF and the RAM area $A000-$BFFF.
+func (c *Cartridge) Read(address uint16) uint8 {
+	switch {
+	case address < 0x8000:
+		offset := c.mbc.romOffset(address) % len(c.ROM)
+		return c.ROM[offset]
+
+	case address >= 0xA000 && address < 0xC000:
+		offset, ok := c.mbc.ramOffset(address)
+		if !ok || len(c.RAM) == 0 {
+			return 0xFF
+		}
+		return c.RAM[offset%len(c.RAM)]
+
+	default:
+		return 0xFF
+	}
+}
+
+// Write writes the value to the CPU address, writes to the ROM area control
+// the memory bank controller.
+func (c *Cartridge) Write(address uint16, value uint8) {
+	switch {
+	case address < 0x8000:
+		wasEnabled := c.mbc.ramEnabled()
+		c.mbc.write(address, value)
+		if wasEnabled && !c.mbc.ramEnabled() {
+			c.SaveRAM()
+		}
+
+	case address >= 0xA000 && address < 0xC000:
+		offset, ok := c.mbc.ramOffset(address)
+		if !ok || len(c.RAM) == 0 {
+			return
+		}
+		c.RAM[offset%len(c.RAM)] = value
+		c.ramDirty = true
+	}
+}
+
+// SaveRAM passes the battery backed RAM to the RAM saver if it was written
+// to since it was last saved. It should be called on shutdown, as games
+// that keep the RAM enabled would otherwise lose their save data.
+func (c *Cartridge) SaveRAM() {
+	if !c.ramDirty || !c.Header.Battery || c.opts.ramSaver == nil {
+		return
+	}
+	c.ramDirty = false
+	c.opts.ramSaver(append([]byte(nil), c.RAM...))
+}
@@ -0,0 +1,152 @@
+package cartridge
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+// testRom returns a ROM with the given header values where every bank
+// contains its bank number as marker in the first byte.
+func testRom(typ, romSize, ramSize byte) []byte {
+	banks := 2 << romSize
+	rom := make([]byte, banks*romBankSize)
+	for bank := range banks {
+		rom[bank*romBankSize] = byte(bank)
+	}
+	copy(rom[headerTitle:], "TESTGAME")
+	rom[headerSGBFlag] = 0x03
+	rom[headerType] = typ
+	rom[headerROMSize] = romSize
+	rom[headerRAMSize] = ramSize
+	rom[headerChecksum] = computeChecksum(rom)
+	rom[headerGlobalChecksum] = 0x12
+	rom[headerGlobalChecksum+1] = 0x34
+	return rom
+}
+
+func TestParseHeader(t *testing.T) {
+	rom := testRom(0x1B, 2, 3)
+	rom[headerCGBFlag] = byte(CGBCompatible)
+	rom[headerChecksum] = computeChecksum(rom)
+
+	header, err := ParseHeader(rom)
+	assert.NoError(t, err)
+	assert.Equal(t, "TESTGAME", header.Title)
+	assert.Equal(t, CGBCompatible, header.CGB)
+	assert.True(t, header.SGB)
+	assert.Equal(t, MBC5, header.MBC)
+	assert.True(t, header.Battery)
+	assert.False(t, header.Timer)
+	assert.Equal(t, 8, header.ROMBanks)
+	assert.Equal(t, 32*1024, header.RAMSize)
+	assert.True(t, header.ChecksumValid)
+	assert.Equal(t, 0x1234, header.GlobalChecksum)
+
+	rom[headerChecksum]++
+	header, err = ParseHeader(rom)
+	assert.NoError(t, err)
+	assert.False(t, header.ChecksumValid)
+
+	_, err = ParseHeader(rom[:0x100])
+	assert.Error(t, err, "ROM too small to contain a header")
+}
+
+func TestMBC1(t *testing.T) {
+	cart, err := LoadFile(bytes.NewReader(testRom(0x03, 6, 3))) // 2MB, 32KB RAM
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, cart.Read(0x0000))
+	assert.Equal(t, 1, cart.Read(0x4000))
+
+	cart.Write(0x2000, 0) // bank 0 selects bank 1
+	assert.Equal(t, 1, cart.Read(0x4000))
+	cart.Write(0x2000, 0x05)
+	cart.Write(0x4000, 0x02)
+	assert.Equal(t, 0x45, cart.Read(0x4000))
+	assert.Equal(t, 0, cart.Read(0x0000))
+
+	cart.Write(0x6000, 1) // advanced banking mode
+	assert.Equal(t, 0x40, cart.Read(0x0000))
+
+	cart.Write(0xA000, 0x11)
+	assert.Equal(t, 0xFF, cart.Read(0xA000), "RAM disabled")
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA000, 0x11)
+	assert.Equal(t, 0x11, cart.Read(0xA000))
+	assert.Equal(t, 0x11, cart.RAM[2*ramBankSize])
+}
+
+func TestMBC3(t *testing.T) {
+	cart, err := New(testRom(0x13, 6, 3))
+	assert.NoError(t, err)
+
+	cart.Write(0x2000, 0x65)
+	assert.Equal(t, 0x65, cart.Read(0x4000))
+
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0x4000, 0x01)
+	cart.Write(0xA001, 0x22)
+	assert.Equal(t, 0x22, cart.RAM[ramBankSize+1])
+
+	cart.Write(0x4000, 0x08) // RTC register
+	assert.Equal(t, 0xFF, cart.Read(0xA001))
+}
+
+func TestMBC5(t *testing.T) {
+	cart, err := New(testRom(0x19, 8, 0)) // 8MB
+	assert.NoError(t, err)
+
+	cart.Write(0x2000, 0)
+	assert.Equal(t, 0, cart.Read(0x4000))
+	cart.Write(0x2000, 0x23)
+	cart.Write(0x3000, 1)
+	assert.Equal(t, 0x23, cart.Read(0x4000), "bank 0x123 marker is truncated to a byte")
+	assert.Equal(t, 0x123*romBankSize+5, cart.mbc.romOffset(0x4005))
+}
+
+func TestBatteryRAMSaver(t *testing.T) {
+	var saved []byte
+	cart, err := New(testRom(0x03, 0, 2), WithRAMSaver(func(ram []byte) {
+		saved = bytes.Clone(ram)
+	}))
+	assert.NoError(t, err)
+	assert.NoError(t, cart.LoadRAM(make([]byte, 8*1024)))
+	assert.Error(t, cart.LoadRAM(nil), "RAM data size mismatch")
+
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0x0000, 0x00)
+	assert.Nil(t, saved, "RAM not written")
+
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA000, 0x33)
+	cart.Write(0x0000, 0x00)
+	assert.Len(t, saved, 8*1024)
+	assert.Equal(t, 0x33, saved[0])
+}
+
+func TestSaveRAM(t *testing.T) {
+	var saved []byte
+	cart, err := New(testRom(0x03, 0, 2), WithRAMSaver(func(ram []byte) {
+		saved = ram
+	}))
+	assert.NoError(t, err)
+
+	cart.SaveRAM()
+	assert.Nil(t, saved, "RAM not written")
+
+	// the RAM stays enabled, the save data is only saved on shutdown
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA000, 0x44)
+	assert.Nil(t, saved)
+	cart.SaveRAM()
+	assert.Equal(t, 0x44, saved[0])
+
+	// the saver gets a copy of the RAM
+	cart.Write(0xA000, 0x55)
+	assert.Equal(t, 0x44, saved[0])
+	saved = nil
+	cart.SaveRAM()
+	assert.Equal(t, 0x55, saved[0])
+}
@@ -0,0 +1,184 @@
+package cartridge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+const (
+	headerTitle          = 0x134
+	headerCGBFlag        = 0x143
+	headerSGBFlag        = 0x146
+	headerType           = 0x147
+	headerROMSize        = 0x148
+	headerRAMSize        = 0x149
+	headerChecksum       = 0x14D
+	headerGlobalChecksum = 0x14E
+	headerEnd            = 0x150
+
+	romBankSize = 0x4000
+	ramBankSize = 0x2000
+)
+
+// CGBMode defines the Game Boy Color support of a cartridge.
+type CGBMode byte
+
+const (
+	CGBNone       CGBMode = 0x00 // DMG only
+	CGBCompatible CGBMode = 0x80 // supports CGB functions, works on DMG
+	CGBOnly       CGBMode = 0xC0 // requires a CGB
+)
+
+// MBC defines the memory bank controller type of a cartridge.
+type MBC int
+
+const (
+	ROMOnly MBC = iota
+	MBC1
+	MBC2
+	MBC3
+	MBC5
+	UnknownMBC
+)
+
+// String returns the name of the memory bank controller.
+func (m MBC) String() string {
+	switch m {
+	case ROMOnly:
+		return "ROM only"
+	case MBC1:
+		return "MBC1"
+	case MBC2:
+		return "MBC2"
+	case MBC3:
+		return "MBC3"
+	case MBC5:
+		return "MBC5"
+	default:
+		return "unknown"
+	}
+}
+
+// Header contains the parsed cartridge header.
+type Header struct {
+	Title string
+	CGB   CGBMode
+	SGB   bool // supports Super Game Boy functions
+
+	Type     byte // raw cartridge type
+	MBC      MBC
+	Battery  bool
+	Timer    bool
+	ROMBanks int // number of 16KB ROM banks
+	RAMSize  int // size of the cartridge RAM in bytes
+
+	Checksum       byte
+	ChecksumValid  bool
+	GlobalChecksum uint16
+}
+
+// ParseHeader parses the header of a cartridge ROM.
+func ParseHeader(rom []byte) (Header, error) {
+	if len(rom) < headerEnd {
+		return Header{}, errors.New("ROM too small to contain a header")
+	}
+
+	header := Header{
+		CGB:            CGBMode(rom[headerCGBFlag] & 0xC0),
+		SGB:            rom[headerSGBFlag] == 0x03,
+		Type:           rom[headerType],
+		Checksum:       rom[headerChecksum],
+		GlobalChecksum: uint16(rom[headerGlobalChecksum])<<8 | uint16(rom[headerGlobalChecksum+1]),
+	}
+	if header.CGB == 0x40 {
+		header.CGB = CGBNone
+	}
+
+	title := rom[headerTitle:headerCGBFlag]
+	if header.CGB == CGBNone {
+		title = rom[headerTitle : headerCGBFlag+1]
+	}
+	if i := bytes.IndexByte(title, 0); i >= 0 {
+		title = title[:i]
+	}
+	header.Title = string(bytes.TrimSpace(title))
+
+	header.MBC, header.Battery, header.Timer = cartridgeType(header.Type)
+
+	romSize := rom[headerROMSize]
+	if romSize > 8 {
+		return Header{}, fmt.Errorf("unsupported ROM size %02x", romSize)
+	}
+	header.ROMBanks = 2 << romSize
+
+	ramSize, err := ramSizeBytes(rom[headerRAMSize])
+	if err != nil {
+		return Header{}, err
+	}
+	header.RAMSize = ramSize
+	if header.MBC == MBC2 {
+		header.RAMSize = 512
+	}
+
+	header.ChecksumValid = computeChecksum(rom) == header.Checksum
+	return header, nil
+}
+
+// cartridgeType returns the memory bank controller and the battery and timer
+// presence of a cartridge type.
+func cartridgeType(typ byte) (MBC, bool, bool) {
+	switch typ {
+	case 0x00, 0x08:
+		return ROMOnly, false, false
+	case 0x09:
+		return ROMOnly, true, false
+	case 0x01, 0x02:
+		return MBC1, false, false
+	case 0x03:
+		return MBC1, true, false
+	case 0x05:
+		return MBC2, false, false
+	case 0x06:
+		return MBC2, true, false
+	case 0x0F, 0x10:
+		return MBC3, true, true
+	case 0x11, 0x12:
+		return MBC3, false, false
+	case 0x13:
+		return MBC3, true, false
+	case 0x19, 0x1A, 0x1C, 0x1D:
+		return MBC5, false, false
+	case 0x1B, 0x1E:
+		return MBC5, true, false
+	default:
+		return UnknownMBC, false, false
+	}
+}
+
+func ramSizeBytes(value byte) (int, error) {
+	switch value {
+	case 0x00, 0x01:
+		return 0, nil
+	case 0x02:
+		return 8 * 1024, nil
+	case 0x03:
+		return 32 * 1024, nil
+	case 0x04:
+		return 128 * 1024, nil
+	case 0x05:
+		return 64 * 1024, nil
+	default:
+		return 0, fmt.Errorf("unsupported RAM size %02x", value)
+	}
+}
+
+// computeChecksum calculates the header checksum over the title to the mask
+// ROM version number.
+func computeChecksum(rom []byte) byte {
+	var checksum byte
+	for _, b := range rom[headerTitle:headerChecksum] {
+		checksum = checksum - b - 1
+	}
+	return checksum
+}
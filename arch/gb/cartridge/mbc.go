@@ -0,0 +1,148 @@
+package cartridge
+
+// mbc is a memory bank controller that maps CPU addresses to offsets in
+// the ROM and RAM of the cartridge.
+type mbc interface {
+	romOffset(address uint16) int
+	// ramOffset returns the RAM offset and whether the RAM is accessible.
+	ramOffset(address uint16) (int, bool)
+	ramEnabled() bool
+	write(address uint16, value uint8)
+}
+
+// bankedOffset returns the offset of the address inside of the bank.
+func bankedOffset(bank int, bankSize int, address uint16) int {
+	return bank*bankSize + int(address)&(bankSize-1)
+}
+
+// romOnly is used by cartridges without memory bank controller, they
+// optionally contain up to 8KB of RAM.
+type romOnly struct{}
+
+func (m *romOnly) romOffset(address uint16) int {
+	return int(address)
+}
+
+func (m *romOnly) ramOffset(address uint16) (int, bool) {
+	return int(address - 0xA000), true
+}
+
+func (m *romOnly) ramEnabled() bool { return true }
+
+func (m *romOnly) write(uint16, uint8) {}
+
+// mbc1 supports up to 2MB ROM and 32KB RAM. The 2 bit bank2 register
+// either extends the ROM bank number or selects the RAM bank, based on
+// the banking mode.
+type mbc1 struct {
+	enabled bool
+	bank1   int // 5 bit ROM bank number
+	bank2   int
+	mode    uint8
+}
+
+func (m *mbc1) romOffset(address uint16) int {
+	if address < romBankSize {
+		bank := 0
+		if m.mode == 1 {
+			bank = m.bank2 << 5
+		}
+		return bankedOffset(bank, romBankSize, address)
+	}
+	return bankedOffset(m.bank2<<5|m.bank1, romBankSize, address)
+}
+
+func (m *mbc1) ramOffset(address uint16) (int, bool) {
+	bank := 0
+	if m.mode == 1 {
+		bank = m.bank2
+	}
+	return bankedOffset(bank, ramBankSize, address), m.enabled
+}
+
+func (m *mbc1) ramEnabled() bool { return m.enabled }
+
+func (m *mbc1) write(address uint16, value uint8) {
+	switch {
+	case address < 0x2000:
+		m.enabled = value&0x0F == 0x0A
+	case address < 0x4000:
+		m.bank1 = int(value & 0x1F)
+		if m.bank1 == 0 {
+			m.bank1 = 1
+		}
+	case address < 0x6000:
+		m.bank2 = int(value & 0x03)
+	default:
+		m.mode = value & 1
+	}
+}
+
+// mbc3 supports up to 2MB ROM and 32KB RAM. The real time clock registers
+// are not emulated, selecting them disables the RAM area.
+type mbc3 struct {
+	enabled bool
+	romBank int
+	ramBank int
+}
+
+func (m *mbc3) romOffset(address uint16) int {
+	if address < romBankSize {
+		return int(address)
+	}
+	return bankedOffset(m.romBank, romBankSize, address)
+}
+
+func (m *mbc3) ramOffset(address uint16) (int, bool) {
+	return bankedOffset(m.ramBank, ramBankSize, address), m.enabled && m.ramBank < 4
+}
+
+func (m *mbc3) ramEnabled() bool { return m.enabled }
+
+func (m *mbc3) write(address uint16, value uint8) {
+	switch {
+	case address < 0x2000:
+		m.enabled = value&0x0F == 0x0A
+	case address < 0x4000:
+		m.romBank = int(value & 0x7F)
+		if m.romBank == 0 {
+			m.romBank = 1
+		}
+	case address < 0x6000:
+		m.ramBank = int(value)
+	}
+}
+
+// mbc5 supports up to 8MB ROM and 128KB RAM, unlike the other controllers
+// it allows mapping ROM bank 0 into the switchable area.
+type mbc5 struct {
+	enabled bool
+	romBank int // 9 bit ROM bank number
+	ramBank int
+}
+
+func (m *mbc5) romOffset(address uint16) int {
+	if address < romBankSize {
+		return int(address)
+	}
+	return bankedOffset(m.romBank, romBankSize, address)
+}
+
+func (m *mbc5) ramOffset(address uint16) (int, bool) {
+	return bankedOffset(m.ramBank, ramBankSize, address), m.enabled
+}
+
+func (m *mbc5) ramEnabled() bool { return m.enabled }
+
+func (m *mbc5) write(address uint16, value uint8) {
+	switch {
+	case address < 0x2000:
+		m.enabled = value&0x0F == 0x0A
+	case address < 0x3000:
+		m.romBank = m.romBank&0x100 | int(value)
+	case address < 0x4000:
+		m.romBank = m.romBank&0xFF | int(value&1)<<8
+	case address < 0x6000:
+		m.ramBank = int(value & 0x0F)
+	}
+}
@@ -0,0 +1,123 @@
+package input
+
+import (
+	"maps"
+
+	"github.com/retroenv/retrogolib/input"
+)
+
+// Button is a button of the standard controller, the values match the order
+// in which the buttons are shifted out.
+type Button uint8
+
+const (
+	ButtonA Button = 1 << iota
+	ButtonB
+	ButtonSelect
+	ButtonStart
+	ButtonUp
+	ButtonDown
+	ButtonLeft
+	ButtonRight
+)
+
+// KeyMap maps keyboard keys to controller buttons.
+type KeyMap map[input.Key]Button
+
+// DefaultKeyMap is the key mapping that new controllers use.
+var DefaultKeyMap = KeyMap{
+	input.Z:         ButtonA,
+	input.X:         ButtonB,
+	input.Backspace: ButtonSelect,
+	input.Enter:     ButtonStart,
+	input.Up:        ButtonUp,
+	input.Down:      ButtonDown,
+	input.Left:      ButtonLeft,
+	input.Right:     ButtonRight,
+}
+
+// Controller emulates the standard controller with its 8 bit shift register.
+// While the strobe line is high the state of the A button is returned
+// continuously, after the strobe line goes low every read shifts out the
+// next button. After all 8 buttons were read, 1 is returned.
+type Controller struct {
+	KeyMap KeyMap
+
+	buttons Button
+	shift   uint8
+	reads   int
+	strobe  bool
+}
+
+// NewController returns a new controller that uses a copy of the default key map.
+func NewController() *Controller {
+	return &Controller{
+		KeyMap: maps.Clone(DefaultKeyMap),
+	}
+}
+
+// SetButton sets the pressed state of the button.
+func (c *Controller) SetButton(button Button, pressed bool) {
+	if pressed {
+		c.buttons |= button
+	} else {
+		c.buttons &^= button
+	}
+	if c.strobe {
+		c.reload()
+	}
+}
+
+// Buttons returns the currently pressed buttons.
+func (c *Controller) Buttons() Button {
+	return c.buttons
+}
+
+// KeyDown presses the button that the key is mapped to and returns whether
+// the key is mapped.
+func (c *Controller) KeyDown(key input.Key) bool {
+	button, ok := c.KeyMap[key]
+	if ok {
+		c.SetButton(button, true)
+	}
+	return ok
+}
+
+// KeyUp releases the button that the key is mapped to and returns whether
+// the key is mapped.
+func (c *Controller) KeyUp(key input.Key) bool {
+	button, ok := c.KeyMap[key]
+	if ok {
+		c.SetButton(button, false)
+	}
+	return ok
+}
+
+// Read returns the next button state in bit 0.
+func (c *Controller) Read() uint8 {
+	if c.strobe {
+		return uint8(c.buttons & ButtonA)
+	}
+	if c.reads >= 8 {
+		return 1
+	}
+
+	value := c.shift & 1
+	c.shift >>= 1
+	c.reads++
+	return value
+}
+
+// Write sets the strobe line, while it is high the button states are
+// reloaded into the shift register.
+func (c *Controller) Write(value uint8) {
+	c.strobe = value&1 != 0
+	if c.strobe {
+		c.reload()
+	}
+}
+
+func (c *Controller) reload() {
+	c.shift = uint8(c.buttons)
+	c.reads = 0
+}
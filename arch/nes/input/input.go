@@ -0,0 +1,49 @@
+// Package input implements the NES controller port devices like the standard
+// controller and the Zapper light gun, bridged to the keyboard keys of the
+// gui input events.
+package input
+
+// Device is a device that is connected to a controller port.
+type Device interface {
+	// Read returns the data lines of the port, only the lower 5 bits are
+	// driven by the device.
+	Read() uint8
+	// Write receives the value written to $4016, bit 0 is the strobe line.
+	Write(value uint8)
+}
+
+// Ports connects the devices of both controller ports to the $4016 and
+// $4017 registers. It implements the bus device interface, only the lowest
+// address bit is decoded, which supports the absolute register addresses as
+// well as the relative addresses of a bus mapping that starts at $4016.
+type Ports struct {
+	Port1 Device
+	Port2 Device
+}
+
+// Read returns the data of the device connected to the port of the address.
+// Unconnected ports return 0.
+func (p *Ports) Read(address uint16) uint8 {
+	device := p.Port1
+	if address&1 != 0 { // $4017
+		device = p.Port2
+	}
+	if device == nil {
+		return 0
+	}
+	return device.Read()
+}
+
+// Write passes writes to $4016 to both ports, writes to $4017 are ignored
+// as the address belongs to the APU frame counter.
+func (p *Ports) Write(address uint16, value uint8) {
+	if address&1 != 0 { // $4017
+		return
+	}
+	if p.Port1 != nil {
+		p.Port1.Write(value)
+	}
+	if p.Port2 != nil {
+		p.Port2.Write(value)
+	}
+}
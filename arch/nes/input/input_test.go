@@ -0,0 +1,72 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/arch/bus"
+	"github.com/retroenv/retrogolib/arch/nes/register"
+	"github.com/retroenv/retrogolib/assert"
+	"github.com/retroenv/retrogolib/input"
+)
+
+func TestControllerShift(t *testing.T) {
+	c := NewController()
+	ports := &Ports{Port1: c}
+	c.SetButton(ButtonA, true)
+	assert.True(t, c.KeyDown(input.Enter))
+	assert.False(t, c.KeyDown(input.F1))
+	c.SetButton(ButtonRight, true)
+
+	ports.Write(register.JOYPAD1, 1)
+	assert.Equal(t, 1, ports.Read(register.JOYPAD1))
+	assert.Equal(t, 1, ports.Read(register.JOYPAD1), "strobe high returns button A")
+	ports.Write(register.JOYPAD1, 0)
+
+	var bits []uint8
+	for range 10 {
+		bits = append(bits, ports.Read(register.JOYPAD1))
+	}
+	assert.Equal(t, []uint8{1, 0, 0, 1, 0, 0, 0, 1, 1, 1}, bits)
+	assert.Equal(t, 0, ports.Read(register.JOYPAD2))
+
+	// changes while the strobe line is low are not visible until the next strobe
+	assert.True(t, c.KeyUp(input.Enter))
+	ports.Write(register.JOYPAD1, 1)
+	ports.Write(register.JOYPAD1, 0)
+	bits = bits[:0]
+	for range 4 {
+		bits = append(bits, ports.Read(register.JOYPAD1))
+	}
+	assert.Equal(t, []uint8{1, 0, 0, 0}, bits)
+}
+
+func TestPortsBus(t *testing.T) {
+	c := NewController()
+	c.SetButton(ButtonA, true)
+	z := NewZapper(func(x, y int) bool { return false })
+
+	b := bus.New()
+	assert.NoError(t, b.Map(register.JOYPAD1, register.JOYPAD2, &Ports{Port1: c, Port2: z}))
+
+	b.Write(register.JOYPAD1, 1)
+	b.Write(register.JOYPAD1, 0)
+	assert.Equal(t, 1, b.Read(register.JOYPAD1))
+	assert.Equal(t, 0, b.Read(register.JOYPAD1))
+	assert.Equal(t, zapperLightNotSensed, b.Read(register.JOYPAD2))
+}
+
+func TestZapper(t *testing.T) {
+	z := NewZapper(func(x, y int) bool {
+		return x == 10 && y == 20
+	})
+	ports := &Ports{Port2: z}
+
+	assert.Equal(t, zapperLightNotSensed, ports.Read(register.JOYPAD2))
+	z.Aim(10, 20)
+	assert.Equal(t, 0, ports.Read(register.JOYPAD2))
+	assert.True(t, z.KeyDown(input.Space))
+	assert.Equal(t, zapperTriggerPulled, ports.Read(register.JOYPAD2))
+	assert.True(t, z.KeyUp(input.Space))
+	assert.False(t, z.KeyUp(input.A))
+	assert.Equal(t, 0, ports.Read(register.JOYPAD2))
+}
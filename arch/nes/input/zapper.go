@@ -0,0 +1,77 @@
+package input
+
+import "github.com/retroenv/retrogolib/input"
+
+const (
+	zapperLightNotSensed = 1 << 3
+	zapperTriggerPulled  = 1 << 4
+)
+
+// DefaultZapperTriggerKey is the key that pulls the trigger of new Zappers.
+const DefaultZapperTriggerKey = input.Space
+
+// Zapper emulates the Zapper light gun. The light sensor is emulated by the
+// Sense function, which gets called with the aimed screen position and
+// returns whether the pixel at the position is bright enough.
+type Zapper struct {
+	TriggerKey input.Key
+	Sense      func(x, y int) bool
+
+	x, y    int
+	trigger bool
+}
+
+// NewZapper returns a new Zapper that uses the given light sense function.
+func NewZapper(sense func(x, y int) bool) *Zapper {
+	return &Zapper{
+		TriggerKey: DefaultZapperTriggerKey,
+		Sense:      sense,
+	}
+}
+
+// Aim sets the screen position that the Zapper points at.
+func (z *Zapper) Aim(x, y int) {
+	z.x = x
+	z.y = y
+}
+
+// SetTrigger sets whether the trigger is pulled.
+func (z *Zapper) SetTrigger(pulled bool) {
+	z.trigger = pulled
+}
+
+// KeyDown pulls the trigger if the key is the trigger key and returns
+// whether the key is mapped.
+func (z *Zapper) KeyDown(key input.Key) bool {
+	if key != z.TriggerKey {
+		return false
+	}
+	z.trigger = true
+	return true
+}
+
+// KeyUp releases the trigger if the key is the trigger key and returns
+// whether the key is mapped.
+func (z *Zapper) KeyUp(key input.Key) bool {
+	if key != z.TriggerKey {
+		return false
+	}
+	z.trigger = false
+	return true
+}
+
+// Read returns the light sensor state in bit 3, which is 0 when light is
+// sensed, and the trigger state in bit 4.
+func (z *Zapper) Read() uint8 {
+	var value uint8
+	if z.Sense == nil || !z.Sense(z.x, z.y) {
+		value |= zapperLightNotSensed
+	}
+	if z.trigger {
+		value |= zapperTriggerPulled
+	}
+	return value
+}
+
+// Write is ignored as the Zapper does not use the strobe line.
+func (z *Zapper) Write(uint8) {}
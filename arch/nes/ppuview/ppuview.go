@@ -0,0 +1,169 @@
+// Package ppuview renders the graphics state of the NES PPU like pattern
+// tables, name tables, sprites and palettes into images for debugger UIs.
+package ppuview
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/retroenv/retrogolib/arch/nes"
+)
+
+const (
+	tileSize         = 8
+	tileBytes        = 16
+	patternTableSize = 0x1000
+	attributeOffset  = 0x3C0
+	nameTableColumns = 32
+	nameTableRows    = 30
+	spriteCount      = 64
+	swatchSize       = 8
+
+	controlSpritePatternTable     = 1 << 3
+	controlBackgroundPatternTable = 1 << 4
+	controlSprite8x16             = 1 << 5
+
+	spritePaletteMask = 0x03
+	spriteFlipH       = 1 << 6
+	spriteFlipV       = 1 << 7
+)
+
+// State contains the PPU memory that the images are rendered from. Memory
+// regions that are shorter than expected are treated as zeroes.
+type State struct {
+	CHR        []byte // pattern tables, 0x2000 bytes
+	NameTables []byte // 4 name tables including attribute tables, mirroring already applied
+	Palette    []byte // palette RAM, 32 bytes
+	OAM        []byte // object attribute memory, 256 bytes
+	Control    uint8  // value of the PPU_CTRL register
+}
+
+// PatternTable renders the 256 tiles of the pattern table (0 or 1) into a
+// 128x128 image, using the given palette (0-7) of the palette RAM.
+func (s State) PatternTable(table, palette int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 16*tileSize, 16*tileSize))
+	colors := s.paletteColors(palette)
+	for tile := range 256 {
+		x, y := tile%16*tileSize, tile/16*tileSize
+		s.drawTile(img, x, y, table*patternTableSize+tile*tileBytes, colors, false, false, false)
+	}
+	return img
+}
+
+// NameTable renders the name table (0-3) with its attributes into a
+// 256x240 image, using the background pattern table selected by PPU_CTRL.
+func (s State) NameTable(index int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, nameTableColumns*tileSize, nameTableRows*tileSize))
+	base := index * nes.NameTableSize
+	patternBase := 0
+	if s.Control&controlBackgroundPatternTable != 0 {
+		patternBase = patternTableSize
+	}
+
+	for row := range nameTableRows {
+		for column := range nameTableColumns {
+			tile := int(byteAt(s.NameTables, base+row*nameTableColumns+column))
+			attribute := byteAt(s.NameTables, base+attributeOffset+row/4*8+column/4)
+			shift := (row%4/2)*4 + (column%4/2)*2
+			palette := int(attribute>>shift) & 3
+
+			colors := s.paletteColors(palette)
+			s.drawTile(img, column*tileSize, row*tileSize, patternBase+tile*tileBytes, colors, false, false, false)
+		}
+	}
+	return img
+}
+
+// Sprites renders the 64 sprites of the OAM in a grid of 8x8 cells into a
+// 64x128 image, every cell is 8x16 pixels to fit 8x16 sprites. Flipping is
+// applied and transparent pixels stay transparent.
+func (s State) Sprites() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 8*tileSize, 8*2*tileSize))
+	for sprite := range spriteCount {
+		tile := int(byteAt(s.OAM, sprite*4+1))
+		attributes := byteAt(s.OAM, sprite*4+2)
+		colors := s.paletteColors(4 + int(attributes&spritePaletteMask))
+		flipH := attributes&spriteFlipH != 0
+		flipV := attributes&spriteFlipV != 0
+		x, y := sprite%8*tileSize, sprite/8*2*tileSize
+
+		if s.Control&controlSprite8x16 == 0 {
+			patternBase := 0
+			if s.Control&controlSpritePatternTable != 0 {
+				patternBase = patternTableSize
+			}
+			s.drawTile(img, x, y, patternBase+tile*tileBytes, colors, flipH, flipV, true)
+			continue
+		}
+
+		top := (tile&1)*patternTableSize + (tile&0xFE)*tileBytes
+		bottom := top + tileBytes
+		if flipV {
+			top, bottom = bottom, top
+		}
+		s.drawTile(img, x, y, top, colors, flipH, flipV, true)
+		s.drawTile(img, x, y+tileSize, bottom, colors, flipH, flipV, true)
+	}
+	return img
+}
+
+// Palettes renders the 32 entries of the palette RAM as 8x8 swatches into a
+// 128x16 image, the first row contains the background palettes and the
+// second row the sprite palettes.
+func (s State) Palettes() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 16*swatchSize, 2*swatchSize))
+	for entry := range nes.PaletteSize {
+		c := SystemPalette[byteAt(s.Palette, entry)&0x3F]
+		x, y := entry%16*swatchSize, entry/16*swatchSize
+		for py := range swatchSize {
+			for px := range swatchSize {
+				img.SetRGBA(x+px, y+py, c)
+			}
+		}
+	}
+	return img
+}
+
+// paletteColors returns the 4 colors of the palette (0-7), the first color
+// is the universal background color.
+func (s State) paletteColors(palette int) [4]color.RGBA {
+	var colors [4]color.RGBA
+	colors[0] = SystemPalette[byteAt(s.Palette, 0)&0x3F]
+	for i := 1; i < 4; i++ {
+		colors[i] = SystemPalette[byteAt(s.Palette, palette*4+i)&0x3F]
+	}
+	return colors
+}
+
+// drawTile draws the 8x8 tile at the CHR offset into the image. If
+// transparent is set, pixels of color 0 are not drawn.
+func (s State) drawTile(img *image.RGBA, x, y, offset int, colors [4]color.RGBA, flipH, flipV, transparent bool) {
+	for row := range tileSize {
+		low := byteAt(s.CHR, offset+row)
+		high := byteAt(s.CHR, offset+row+tileSize)
+		py := row
+		if flipV {
+			py = tileSize - 1 - row
+		}
+
+		for column := range tileSize {
+			bit := 7 - column
+			value := (low>>bit)&1 | ((high>>bit)&1)<<1
+			if value == 0 && transparent {
+				continue
+			}
+			px := column
+			if flipH {
+				px = tileSize - 1 - column
+			}
+			img.SetRGBA(x+px, y+py, colors[value])
+		}
+	}
+}
+
+func byteAt(data []byte, offset int) byte {
+	if offset < 0 || offset >= len(data) {
+		return 0
+	}
+	return data[offset]
+}
@@ -0,0 +1,70 @@
+package ppuview
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func testState() State {
+	s := State{
+		CHR:        make([]byte, 0x2000),
+		NameTables: make([]byte, 0x1000),
+		Palette:    make([]byte, 32),
+		OAM:        make([]byte, 256),
+	}
+	// tile 1: first row has color 1 in the leftmost pixel and color 3 in the rightmost pixel
+	s.CHR[16] = 0b1000_0001
+	s.CHR[16+8] = 0b0000_0001
+	s.Palette[0] = 0x0F // black background
+	s.Palette[1] = 0x30
+	s.Palette[3] = 0x16
+	s.Palette[5] = 0x2A
+	s.Palette[17] = 0x11
+	return s
+}
+
+func TestPatternTable(t *testing.T) {
+	s := testState()
+	img := s.PatternTable(0, 0)
+	assert.Equal(t, 128, img.Rect.Dx())
+	assert.Equal(t, SystemPalette[0x0F], img.RGBAAt(0, 0))
+	assert.Equal(t, SystemPalette[0x30], img.RGBAAt(8, 0))
+	assert.Equal(t, SystemPalette[0x16], img.RGBAAt(15, 0))
+}
+
+func TestNameTable(t *testing.T) {
+	s := testState()
+	s.NameTables[0x400+33] = 1    // second name table, tile at column 1 row 1
+	s.NameTables[0x400+0x3C0] = 1 // top left attribute uses palette 1
+
+	img := s.NameTable(1)
+	assert.Equal(t, 256, img.Rect.Dx())
+	assert.Equal(t, 240, img.Rect.Dy())
+	assert.Equal(t, SystemPalette[0x2A], img.RGBAAt(8, 8))
+	assert.Equal(t, SystemPalette[0x0F], img.RGBAAt(9, 8))
+}
+
+func TestSprites(t *testing.T) {
+	s := testState()
+	s.OAM[1] = 1    // tile
+	s.OAM[2] = 0x40 // horizontal flip, palette 4
+
+	img := s.Sprites()
+	assert.Equal(t, SystemPalette[0x11], img.RGBAAt(7, 0))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(1, 0), "transparent pixel")
+
+	s.Control = controlSprite8x16
+	s.OAM[1] = 0    // tiles 0 and 1 of the first pattern table
+	s.OAM[2] = 0x80 // vertical flip
+	img = s.Sprites()
+	assert.Equal(t, SystemPalette[0x11], img.RGBAAt(0, 7), "bottom tile is drawn flipped at the top")
+}
+
+func TestPalettes(t *testing.T) {
+	s := testState()
+	img := s.Palettes()
+	assert.Equal(t, SystemPalette[0x30], img.RGBAAt(8, 0))
+	assert.Equal(t, SystemPalette[0x11], img.RGBAAt(8, 8))
+}
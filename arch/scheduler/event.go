@@ -0,0 +1,34 @@
+package scheduler
+
+type event struct {
+	id       EventID
+	cycle    uint64
+	callback func()
+}
+
+// eventQueue is a priority queue of events ordered by cycle and scheduling order.
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].cycle == q[j].cycle {
+		return q[i].id < q[j].id
+	}
+	return q[i].cycle < q[j].cycle
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x any) {
+	*q = append(*q, x.(*event))
+}
+
+func (q *eventQueue) Pop() any {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return e
+}
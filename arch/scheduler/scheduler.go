@@ -0,0 +1,162 @@
+// Package scheduler provides a cycle based scheduler that interleaves the
+// execution of the components of a system like CPU, PPU and APU and executes
+// events at scheduled cycles.
+package scheduler
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+)
+
+// Component is a system component that gets executed by the scheduler.
+type Component interface {
+	// Step executes the next unit of work, like an instruction, and returns
+	// the amount of cycles in the clock of the component that it took.
+	Step() (uint64, error)
+}
+
+// EventID identifies a scheduled event.
+type EventID uint64
+
+// Scheduler executes components and events in master clock cycle order.
+// The component that is furthest behind in time is always executed next,
+// events are executed as soon as all components reached the event cycle.
+type Scheduler struct {
+	components []*component
+	events     eventQueue
+	cancelled  map[EventID]struct{}
+
+	cycles  uint64 // current master clock cycle
+	nextID  EventID
+	stopped bool
+}
+
+type component struct {
+	component Component
+	divider   uint64 // master clock cycles per component cycle
+	clock     uint64 // master clock cycle that the component reached
+}
+
+// New returns a new scheduler.
+func New() *Scheduler {
+	return &Scheduler{
+		cancelled: map[EventID]struct{}{},
+	}
+}
+
+// Add adds a component to the scheduler. The divider is the number of master
+// clock cycles per component cycle, for example 12 for the CPU of an NTSC NES
+// with a master clock of 21.477 MHz.
+func (s *Scheduler) Add(c Component, divider uint64) error {
+	if c == nil {
+		return errors.New("component is nil")
+	}
+	if divider == 0 {
+		return errors.New("divider must not be 0")
+	}
+
+	s.components = append(s.components, &component{
+		component: c,
+		divider:   divider,
+		clock:     s.cycles,
+	})
+	return nil
+}
+
+// Cycles returns the current master clock cycle.
+func (s *Scheduler) Cycles() uint64 {
+	return s.cycles
+}
+
+// Schedule schedules the callback to be executed after the given amount of
+// master clock cycles, relative to the current cycle.
+func (s *Scheduler) Schedule(cycles uint64, callback func()) EventID {
+	s.nextID++
+	heap.Push(&s.events, &event{
+		id:       s.nextID,
+		cycle:    s.cycles + cycles,
+		callback: callback,
+	})
+	return s.nextID
+}
+
+// Cancel cancels a scheduled event that was not executed yet.
+func (s *Scheduler) Cancel(id EventID) {
+	for _, e := range s.events {
+		if e.id == id {
+			s.cancelled[id] = struct{}{}
+			return
+		}
+	}
+}
+
+// Stop stops the current run after the executing component step or event
+// callback returns. It can be used by callbacks to run until an event.
+func (s *Scheduler) Stop() {
+	s.stopped = true
+}
+
+// RunFor executes components and events for the given amount of master clock cycles.
+func (s *Scheduler) RunFor(cycles uint64) error {
+	return s.RunUntil(s.cycles + cycles)
+}
+
+// RunUntil executes components and events until the master clock reaches the
+// given cycle or the run is stopped. Components can overshoot the cycle by
+// the length of their last step.
+func (s *Scheduler) RunUntil(cycle uint64) error {
+	s.stopped = false
+
+	for !s.stopped {
+		s.runEvents()
+		if s.stopped || s.cycles >= cycle {
+			return nil
+		}
+
+		next := s.nextComponent()
+		if next == nil {
+			s.cycles = s.nextCycle(cycle)
+			continue
+		}
+
+		cycles, err := next.component.Step()
+		if err != nil {
+			return fmt.Errorf("executing component step: %w", err)
+		}
+		next.clock += max(cycles, 1) * next.divider
+		s.cycles = s.nextComponent().clock
+	}
+	return nil
+}
+
+// nextComponent returns the component that is furthest behind in time.
+func (s *Scheduler) nextComponent() *component {
+	var next *component
+	for _, c := range s.components {
+		if next == nil || c.clock < next.clock {
+			next = c
+		}
+	}
+	return next
+}
+
+// nextCycle returns the cycle of the next event or the target cycle if it is earlier.
+func (s *Scheduler) nextCycle(target uint64) uint64 {
+	if len(s.events) > 0 && s.events[0].cycle < target {
+		return s.events[0].cycle
+	}
+	return target
+}
+
+// runEvents executes all events that are due at the current cycle.
+func (s *Scheduler) runEvents() {
+	for len(s.events) > 0 && s.events[0].cycle <= s.cycles && !s.stopped {
+		e := heap.Pop(&s.events).(*event)
+		if _, ok := s.cancelled[e.id]; ok {
+			delete(s.cancelled, e.id)
+			continue
+		}
+		e.callback()
+	}
+}
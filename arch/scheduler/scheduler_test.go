@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+type testComponent struct {
+	name   string
+	cycles uint64
+	steps  int
+	log    *[]string
+	err    error
+}
+
+func (c *testComponent) Step() (uint64, error) {
+	c.steps++
+	*c.log = append(*c.log, c.name)
+	return c.cycles, c.err
+}
+
+func TestSchedulerInterleaving(t *testing.T) {
+	var log []string
+	cpu := &testComponent{name: "cpu", cycles: 2, log: &log}
+	ppu := &testComponent{name: "ppu", cycles: 1, log: &log}
+
+	s := New()
+	assert.NoError(t, s.Add(cpu, 3))
+	assert.NoError(t, s.Add(ppu, 1))
+	assert.NoError(t, s.RunFor(12))
+
+	assert.Equal(t, 2, cpu.steps)
+	assert.Equal(t, 12, ppu.steps)
+	assert.Equal(t, uint64(12), s.Cycles())
+	assert.Equal(t, []string{"cpu", "ppu", "ppu", "ppu", "ppu", "ppu", "ppu", "cpu"}, log[:8])
+}
+
+func TestSchedulerEvents(t *testing.T) {
+	var log []string
+	cpu := &testComponent{name: "cpu", cycles: 1, log: &log}
+
+	s := New()
+	assert.NoError(t, s.Add(cpu, 1))
+
+	s.Schedule(3, func() { log = append(log, "vblank") })
+	cancelled := s.Schedule(2, func() { log = append(log, "cancelled") })
+	s.Cancel(cancelled)
+	s.Schedule(5, s.Stop)
+
+	assert.NoError(t, s.RunFor(100))
+	assert.Equal(t, uint64(5), s.Cycles())
+	assert.Equal(t, []string{"cpu", "cpu", "cpu", "vblank", "cpu", "cpu"}, log)
+}
+
+func TestSchedulerWithoutComponents(t *testing.T) {
+	s := New()
+	fired := 0
+	s.Schedule(10, func() { fired++ })
+
+	assert.NoError(t, s.RunFor(20))
+	assert.Equal(t, 1, fired)
+	assert.Equal(t, uint64(20), s.Cycles())
+}
+
+func TestSchedulerError(t *testing.T) {
+	var log []string
+	errTest := errors.New("test")
+	s := New()
+	assert.NoError(t, s.Add(&testComponent{name: "cpu", log: &log, err: errTest}, 1))
+	assert.ErrorIs(t, s.RunFor(1), errTest)
+	assert.Error(t, s.Add(nil, 1), "component is nil")
+}
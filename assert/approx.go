@@ -0,0 +1,128 @@
+package assert
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"reflect"
+)
+
+// InDelta asserts that the two numbers differ by at most delta.
+func InDelta(t Testing, expected, actual any, delta float64, msgAndArgs ...any) {
+	t.Helper()
+
+	exp, act, ok := toFloats(expected, actual)
+	if !ok {
+		msg := fmt.Sprintf("Parameters must be numerical: \nexpected: %T\nactual  : %T", expected, actual)
+		fail(t, msg, msgAndArgs...)
+		return
+	}
+
+	difference := math.Abs(exp - act)
+	if difference <= delta {
+		return
+	}
+
+	msg := fmt.Sprintf("Difference %v exceeds delta %v: \nexpected: %v\nactual  : %v",
+		difference, delta, expected, actual)
+	fail(t, msg, msgAndArgs...)
+}
+
+// InEpsilon asserts that the relative error of the actual number compared
+// to the expected number is at most epsilon.
+func InEpsilon(t Testing, expected, actual any, epsilon float64, msgAndArgs ...any) {
+	t.Helper()
+
+	exp, act, ok := toFloats(expected, actual)
+	if !ok {
+		msg := fmt.Sprintf("Parameters must be numerical: \nexpected: %T\nactual  : %T", expected, actual)
+		fail(t, msg, msgAndArgs...)
+		return
+	}
+	if exp == 0 {
+		fail(t, "Expected value must not be zero to calculate the relative error", msgAndArgs...)
+		return
+	}
+
+	relativeError := math.Abs(exp-act) / math.Abs(exp)
+	if relativeError <= epsilon {
+		return
+	}
+
+	msg := fmt.Sprintf("Relative error %v exceeds epsilon %v: \nexpected: %v\nactual  : %v",
+		relativeError, epsilon, expected, actual)
+	fail(t, msg, msgAndArgs...)
+}
+
+// ImagesEqual asserts that both images have the same bounds and that every
+// color channel of every pixel differs by at most the tolerance, which is
+// given in 8 bit color channel units.
+func ImagesEqual(t Testing, expected, actual image.Image, tolerance uint8, msgAndArgs ...any) {
+	t.Helper()
+
+	if expected.Bounds() != actual.Bounds() {
+		msg := fmt.Sprintf("Image bounds not equal: \nexpected: %v\nactual  : %v", expected.Bounds(), actual.Bounds())
+		fail(t, msg, msgAndArgs...)
+		return
+	}
+
+	var differences int
+	var first image.Point
+	bounds := expected.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if pixelsInTolerance(expected, actual, x, y, tolerance) {
+				continue
+			}
+			if differences == 0 {
+				first = image.Pt(x, y)
+			}
+			differences++
+		}
+	}
+	if differences == 0 {
+		return
+	}
+
+	msg := fmt.Sprintf("Images not equal: %d pixels differ, first at %v\nexpected: %v\nactual  : %v",
+		differences, first, expected.At(first.X, first.Y), actual.At(first.X, first.Y))
+	fail(t, msg, msgAndArgs...)
+}
+
+func pixelsInTolerance(expected, actual image.Image, x, y int, tolerance uint8) bool {
+	er, eg, eb, ea := expected.At(x, y).RGBA()
+	ar, ag, ab, aa := actual.At(x, y).RGBA()
+	for _, channels := range [4][2]uint32{{er, ar}, {eg, ag}, {eb, ab}, {ea, aa}} {
+		// compare in 8 bit units, RGBA returns 16 bit channel values
+		e, a := int(channels[0]>>8), int(channels[1]>>8)
+		if e-a > int(tolerance) || a-e > int(tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// toFloats converts both numbers to float64 and returns whether both
+// parameters are numerical.
+func toFloats(expected, actual any) (float64, float64, bool) {
+	exp, ok := toFloat(expected)
+	if !ok {
+		return 0, 0, false
+	}
+	act, ok := toFloat(actual)
+	return exp, act, ok
+}
+
+func toFloat(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,76 @@
+package assert
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestInDelta(t *testing.T) {
+	tst := &errorCapture{}
+	InDelta(tst, 1.0, float32(1.05), 0.1)
+	InDelta(tst, 10, uint8(12), 2)
+	if tst.failed {
+		t.Error("InDelta failed")
+	}
+
+	tst = &errorCapture{}
+	InDelta(tst, 1.0, 1.5, 0.1)
+	if !tst.failed {
+		t.Error("InDelta failed")
+	}
+
+	tst = &errorCapture{}
+	InDelta(tst, "1", 1, 0.1)
+	if !tst.failed {
+		t.Error("InDelta failed for unsupported type")
+	}
+}
+
+func TestInEpsilon(t *testing.T) {
+	tst := &errorCapture{}
+	InEpsilon(tst, 100, 101, 0.01)
+	if tst.failed {
+		t.Error("InEpsilon failed")
+	}
+
+	tst = &errorCapture{}
+	InEpsilon(tst, 100, 102, 0.01)
+	if !tst.failed {
+		t.Error("InEpsilon failed")
+	}
+
+	tst = &errorCapture{}
+	InEpsilon(tst, 0, 0, 0.01)
+	if !tst.failed {
+		t.Error("InEpsilon failed for zero expected value")
+	}
+}
+
+func TestImagesEqual(t *testing.T) {
+	expected := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	actual := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	expected.SetRGBA(1, 1, color.RGBA{R: 100, A: 255})
+	actual.SetRGBA(1, 1, color.RGBA{R: 102, A: 255})
+
+	tst := &errorCapture{}
+	ImagesEqual(tst, expected, actual, 2)
+	if tst.failed {
+		t.Error("ImagesEqual failed")
+	}
+
+	tst = &errorCapture{}
+	ImagesEqual(tst, expected, actual, 1)
+	if !tst.failed {
+		t.Error("ImagesEqual failed")
+	}
+	if tst.errs[0].(string) != "Images not equal: 1 pixels differ, first at (1,1)\nexpected: {100 0 0 255}\nactual  : {102 0 0 255}" {
+		t.Errorf("unexpected message: %s", tst.errs[0])
+	}
+
+	tst = &errorCapture{}
+	ImagesEqual(tst, expected, image.NewRGBA(image.Rect(0, 0, 1, 1)), 0)
+	if !tst.failed {
+		t.Error("ImagesEqual failed for different bounds")
+	}
+}
@@ -0,0 +1,109 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ElementsMatch asserts that the two collections contain the same elements,
+// ignoring the order. Collections can be slices, arrays or maps, for maps the
+// keys are compared, which allows comparing sets like map[string]struct{}
+// with slices. Duplicate elements of slices have to match in number.
+func ElementsMatch(t Testing, expected, actual any, msgAndArgs ...any) {
+	t.Helper()
+	expectedElements, ok := collectionElements(expected)
+	if !ok {
+		fail(t, fmt.Sprintf("Unsupported collection type %T", expected), msgAndArgs...)
+		return
+	}
+	actualElements, ok := collectionElements(actual)
+	if !ok {
+		fail(t, fmt.Sprintf("Unsupported collection type %T", actual), msgAndArgs...)
+		return
+	}
+
+	missing, extra := diffElements(expectedElements, actualElements)
+	if len(missing) == 0 && len(extra) == 0 {
+		return
+	}
+
+	msg := fmt.Sprintf("Elements not matching: \nmissing: %v\nextra  : %v", missing, extra)
+	fail(t, msg, msgAndArgs...)
+}
+
+// Subset asserts that all elements of the subset are contained in the
+// collection. Collections can be slices, arrays or maps, for maps the keys
+// are compared.
+func Subset(t Testing, collection, subset any, msgAndArgs ...any) {
+	t.Helper()
+	collectionElems, ok := collectionElements(collection)
+	if !ok {
+		fail(t, fmt.Sprintf("Unsupported collection type %T", collection), msgAndArgs...)
+		return
+	}
+	subsetElements, ok := collectionElements(subset)
+	if !ok {
+		fail(t, fmt.Sprintf("Unsupported collection type %T", subset), msgAndArgs...)
+		return
+	}
+
+	_, extra := diffElements(collectionElems, subsetElements)
+	if len(extra) == 0 {
+		return
+	}
+
+	msg := fmt.Sprintf("Elements not contained: %v", extra)
+	fail(t, msg, msgAndArgs...)
+}
+
+// collectionElements returns the elements of a slice or array or the keys
+// of a map.
+func collectionElements(collection any) ([]any, bool) {
+	value := reflect.ValueOf(collection)
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		elements := make([]any, value.Len())
+		for i := range elements {
+			elements[i] = value.Index(i).Interface()
+		}
+		return elements, true
+
+	case reflect.Map:
+		elements := make([]any, 0, value.Len())
+		for _, key := range value.MapKeys() {
+			elements = append(elements, key.Interface())
+		}
+		return elements, true
+
+	default:
+		return nil, false
+	}
+}
+
+// diffElements returns the elements of expected that are missing in actual
+// and the elements of actual that are not in expected.
+func diffElements(expected, actual []any) (missing, extra []any) {
+	matched := make([]bool, len(actual))
+
+	for _, exp := range expected {
+		found := false
+		for i, act := range actual {
+			if !matched[i] && equal(exp, act) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, exp)
+		}
+	}
+
+	for i, act := range actual {
+		if !matched[i] {
+			extra = append(extra, act)
+		}
+	}
+	return missing, extra
+}
@@ -0,0 +1,49 @@
+package assert
+
+import "testing"
+
+func TestElementsMatch(t *testing.T) {
+	tst := &errorCapture{}
+	ElementsMatch(tst, []string{"lda", "sta", "lda"}, []string{"sta", "lda", "lda"})
+	if tst.failed {
+		t.Error("ElementsMatch failed")
+	}
+
+	tst = &errorCapture{}
+	ElementsMatch(tst, []string{"lda", "sta"}, map[string]struct{}{"sta": {}, "lda": {}})
+	if tst.failed {
+		t.Error("ElementsMatch failed for map")
+	}
+
+	tst = &errorCapture{}
+	ElementsMatch(tst, []int{1, 2, 2}, []int{1, 2, 3})
+	if !tst.failed {
+		t.Error("ElementsMatch failed")
+	}
+	if tst.errs[0].(string) != "Elements not matching: \nmissing: [2]\nextra  : [3]" {
+		t.Errorf("unexpected message: %s", tst.errs[0])
+	}
+
+	tst = &errorCapture{}
+	ElementsMatch(tst, 1, []int{1})
+	if !tst.failed {
+		t.Error("ElementsMatch failed for unsupported type")
+	}
+}
+
+func TestSubset(t *testing.T) {
+	tst := &errorCapture{}
+	Subset(tst, map[string]struct{}{"lda": {}, "sta": {}, "jmp": {}}, []string{"jmp", "lda"})
+	if tst.failed {
+		t.Error("Subset failed")
+	}
+
+	tst = &errorCapture{}
+	Subset(tst, []int{1, 2}, []int{2, 4})
+	if !tst.failed {
+		t.Error("Subset failed")
+	}
+	if tst.errs[0].(string) != "Elements not contained: [4]" {
+		t.Errorf("unexpected message: %s", tst.errs[0])
+	}
+}
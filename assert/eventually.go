@@ -0,0 +1,50 @@
+package assert
+
+import (
+	"fmt"
+	"time"
+)
+
+// Eventually asserts that the condition returns true within the timeout,
+// the condition is checked in the given interval.
+func Eventually(t Testing, condition func() bool, timeout, interval time.Duration, msgAndArgs ...any) {
+	t.Helper()
+	if poll(condition, timeout, interval) {
+		return
+	}
+
+	msg := fmt.Sprintf("Condition not satisfied within %s", timeout)
+	fail(t, msg, msgAndArgs...)
+}
+
+// Never asserts that the condition does not return true within the timeout,
+// the condition is checked in the given interval.
+func Never(t Testing, condition func() bool, timeout, interval time.Duration, msgAndArgs ...any) {
+	t.Helper()
+	if !poll(condition, timeout, interval) {
+		return
+	}
+
+	msg := fmt.Sprintf("Condition satisfied within %s", timeout)
+	fail(t, msg, msgAndArgs...)
+}
+
+// poll checks the condition in the interval until it returns true or the
+// timeout is reached. It returns whether the condition returned true.
+// The condition is always checked at least once, waiting between checks
+// does not exceed the timeout.
+func poll(condition func() bool, timeout, interval time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	interval = max(interval, time.Millisecond)
+
+	for {
+		if condition() {
+			return true
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false
+		}
+		time.Sleep(min(interval, remaining))
+	}
+}
@@ -0,0 +1,53 @@
+package assert
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventually(t *testing.T) {
+	var done atomic.Bool
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		done.Store(true)
+	}()
+
+	tst := &errorCapture{}
+	Eventually(tst, done.Load, time.Second, time.Millisecond)
+	if tst.failed {
+		t.Error("Eventually failed")
+	}
+
+	tst = &errorCapture{}
+	Eventually(tst, func() bool { return false }, 5*time.Millisecond, time.Millisecond)
+	if !tst.failed {
+		t.Error("Eventually failed")
+	}
+}
+
+func TestNever(t *testing.T) {
+	tst := &errorCapture{}
+	Never(tst, func() bool { return false }, 5*time.Millisecond, time.Millisecond)
+	if tst.failed {
+		t.Error("Never failed")
+	}
+
+	tst = &errorCapture{}
+	Never(tst, func() bool { return true }, time.Second, time.Millisecond)
+	if !tst.failed {
+		t.Error("Never failed")
+	}
+}
+
+func TestEventuallyIntervalExceedsTimeout(t *testing.T) {
+	tst := &errorCapture{}
+	start := time.Now()
+	Eventually(tst, func() bool { return false }, 5*time.Millisecond, time.Hour)
+	if !tst.failed {
+		t.Error("Eventually failed")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Eventually overshot the timeout, took %s", elapsed)
+	}
+}
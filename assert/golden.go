@@ -0,0 +1,104 @@
+package assert
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"unicode/utf8"
+)
+
+// UpdateGoldenEnv is the environment variable that enables rewriting golden
+// files with the actual output instead of comparing it, if set to true.
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// updateGolden returns whether golden files should be rewritten. This is the
+// case if the UpdateGoldenEnv environment variable is set to true or if the
+// test package defines a boolean -update flag that is set. This package does
+// not register the flag itself to not conflict with flags of importers.
+func updateGolden() bool {
+	if update, err := strconv.ParseBool(os.Getenv(UpdateGoldenEnv)); err == nil && update {
+		return true
+	}
+
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	update, err := strconv.ParseBool(f.Value.String())
+	return err == nil && update
+}
+
+// MatchesGolden asserts that the data matches the content of the golden file.
+// If golden file updating is enabled by the UpdateGoldenEnv environment
+// variable or an -update flag of the test package, the golden file is written
+// with the data instead, including any missing parent directories.
+func MatchesGolden(t Testing, actual []byte, goldenPath string, msgAndArgs ...any) {
+	t.Helper()
+
+	if updateGolden() {
+		if err := writeGolden(actual, goldenPath); err != nil {
+			fail(t, err.Error(), msgAndArgs...)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		msg := fmt.Sprintf("Reading golden file failed, set %s=true to create it:\n%v", UpdateGoldenEnv, err)
+		fail(t, msg, msgAndArgs...)
+		return
+	}
+	if bytes.Equal(expected, actual) {
+		return
+	}
+
+	msg := fmt.Sprintf("Golden file %s not matching:\n%s", goldenPath, goldenDifference(expected, actual))
+	fail(t, msg, msgAndArgs...)
+}
+
+func writeGolden(data []byte, goldenPath string) error {
+	if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+		return fmt.Errorf("creating golden file directory: %w", err)
+	}
+	if err := os.WriteFile(goldenPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing golden file: %w", err)
+	}
+	return nil
+}
+
+// goldenDifference describes the first difference between the expected and
+// actual data, by line for text and by offset for binary data.
+func goldenDifference(expected, actual []byte) string {
+	offset := 0
+	for offset < len(expected) && offset < len(actual) && expected[offset] == actual[offset] {
+		offset++
+	}
+
+	if !isText(expected) || !isText(actual) {
+		return fmt.Sprintf("first difference at offset %d\nexpected size: %d\nactual size  : %d",
+			offset, len(expected), len(actual))
+	}
+
+	line := bytes.Count(expected[:offset], []byte{'\n'}) + 1
+	return fmt.Sprintf("first difference in line %d\nexpected: %q\nactual  : %q",
+		line, lineAt(expected, offset), lineAt(actual, offset))
+}
+
+// lineAt returns the line that contains the offset.
+func lineAt(data []byte, offset int) []byte {
+	start := bytes.LastIndexByte(data[:offset], '\n') + 1
+	end := len(data)
+	if offset < len(data) {
+		if i := bytes.IndexByte(data[offset:], '\n'); i >= 0 {
+			end = offset + i
+		}
+	}
+	return data[start:end]
+}
+
+func isText(data []byte) bool {
+	return utf8.Valid(data) && bytes.IndexByte(data, 0) < 0
+}
@@ -0,0 +1,78 @@
+package assert
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchesGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "trace.golden")
+
+	tst := &errorCapture{}
+	MatchesGolden(tst, []byte("line 1\n"), path)
+	if !tst.failed {
+		t.Error("MatchesGolden failed for missing golden file")
+	}
+
+	t.Setenv(UpdateGoldenEnv, "true")
+	tst = &errorCapture{}
+	MatchesGolden(tst, []byte("line 1\nline 2\n"), path)
+	t.Setenv(UpdateGoldenEnv, "")
+	if tst.failed {
+		t.Error("MatchesGolden failed to update golden file")
+	}
+
+	tst = &errorCapture{}
+	MatchesGolden(tst, []byte("line 1\nline 2\n"), path)
+	if tst.failed {
+		t.Error("MatchesGolden failed")
+	}
+
+	tst = &errorCapture{}
+	MatchesGolden(tst, []byte("line 1\nline 3\n"), path)
+	if !tst.failed {
+		t.Error("MatchesGolden failed")
+	}
+	expected := "Golden file " + path + " not matching:\nfirst difference in line 2\nexpected: \"line 2\"\nactual  : \"line 3\""
+	if tst.errs[0].(string) != expected {
+		t.Errorf("unexpected message: %s", tst.errs[0])
+	}
+}
+
+func TestMatchesGoldenBinary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frame.golden")
+	if err := os.WriteFile(path, []byte{0, 1, 2, 3}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tst := &errorCapture{}
+	MatchesGolden(tst, []byte{0, 1, 5}, path)
+	if !tst.failed {
+		t.Error("MatchesGolden failed")
+	}
+	expected := "Golden file " + path + " not matching:\nfirst difference at offset 2\nexpected size: 4\nactual size  : 3"
+	if tst.errs[0].(string) != expected {
+		t.Errorf("unexpected message: %s", tst.errs[0])
+	}
+}
+
+// updateFlag is the -update flag that test packages commonly define, it is
+// picked up by MatchesGolden.
+var updateFlag = flag.Bool("update", false, "update golden files")
+
+func TestUpdateGoldenFlag(t *testing.T) {
+	if *updateFlag {
+		t.Skip("golden files are updated by the -update flag")
+	}
+	if updateGolden() {
+		t.Fatal("golden update enabled without flag")
+	}
+
+	*updateFlag = true
+	defer func() { *updateFlag = false }()
+	if !updateGolden() {
+		t.Error("golden update not enabled by the -update flag")
+	}
+}
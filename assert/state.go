@@ -0,0 +1,94 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StateEqual asserts that two state structs like CPU register snapshots are
+// equal. Only the differing fields are reported, nested structs are compared
+// field by field and unsigned integers are formatted as hexadecimal values.
+// Nested structs without exported fields like time.Time are compared as a
+// whole, using their Equal method if they have one. Pointers to structs are
+// dereferenced.
+func StateEqual(t Testing, expected, actual any, msgAndArgs ...any) {
+	t.Helper()
+
+	expectedValue := reflect.Indirect(reflect.ValueOf(expected))
+	actualValue := reflect.Indirect(reflect.ValueOf(actual))
+	if expectedValue.Kind() != reflect.Struct || !actualValue.IsValid() || expectedValue.Type() != actualValue.Type() {
+		msg := fmt.Sprintf("Parameters must be structs of the same type: \nexpected: %T\nactual  : %T", expected, actual)
+		fail(t, msg, msgAndArgs...)
+		return
+	}
+
+	var differences []string
+	stateDifferences(&differences, "", expectedValue, actualValue)
+	if len(differences) == 0 {
+		return
+	}
+
+	msg := "State not equal:\n" + strings.Join(differences, "\n")
+	fail(t, msg, msgAndArgs...)
+}
+
+// stateDifferences appends a description of every differing field.
+func stateDifferences(differences *[]string, prefix string, expected, actual reflect.Value) {
+	typ := expected.Type()
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := prefix + field.Name
+		expectedField := expected.Field(i)
+		actualField := actual.Field(i)
+		if field.Type.Kind() == reflect.Struct && hasExportedFields(field.Type) {
+			stateDifferences(differences, name+".", expectedField, actualField)
+			continue
+		}
+
+		if stateValuesEqual(expectedField, actualField) {
+			continue
+		}
+		*differences = append(*differences, fmt.Sprintf("%s: expected %s, actual %s",
+			name, formatStateValue(expectedField), formatStateValue(actualField)))
+	}
+}
+
+// hasExportedFields returns whether the struct type has any exported field.
+func hasExportedFields(typ reflect.Type) bool {
+	for i := range typ.NumField() {
+		if typ.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// stateValuesEqual compares two values of the same type. Types like
+// time.Time that provide an Equal method are compared using it, which
+// also covers structs that only have unexported fields.
+func stateValuesEqual(expected, actual reflect.Value) bool {
+	if method := expected.MethodByName("Equal"); method.IsValid() {
+		typ := method.Type()
+		if typ.NumIn() == 1 && typ.In(0) == expected.Type() &&
+			typ.NumOut() == 1 && typ.Out(0).Kind() == reflect.Bool {
+			return method.Call([]reflect.Value{actual})[0].Bool()
+		}
+	}
+	return reflect.DeepEqual(expected.Interface(), actual.Interface())
+}
+
+// formatStateValue formats unsigned integers as hexadecimal values that are
+// padded to the size of the type.
+func formatStateValue(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return fmt.Sprintf("$%0*X", value.Type().Size()*2, value.Uint())
+	default:
+		return fmt.Sprintf("%v", value.Interface())
+	}
+}
@@ -0,0 +1,89 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testFlags struct {
+	C uint8
+	Z uint8
+}
+
+type testState struct {
+	A      uint8
+	PC     uint16
+	Cycles int
+	Flags  testFlags
+
+	internal bool
+}
+
+func TestStateEqual(t *testing.T) {
+	expected := testState{A: 1, PC: 0x8000, Flags: testFlags{C: 1}, internal: true}
+
+	tst := &errorCapture{}
+	StateEqual(tst, expected, &testState{A: 1, PC: 0x8000, Flags: testFlags{C: 1}})
+	if tst.failed {
+		t.Error("StateEqual failed")
+	}
+
+	tst = &errorCapture{}
+	StateEqual(tst, expected, testState{A: 2, PC: 0x8002, Cycles: 7, Flags: testFlags{C: 1, Z: 1}})
+	if !tst.failed {
+		t.Error("StateEqual failed")
+	}
+	expectedMsg := "State not equal:\n" +
+		"A: expected $01, actual $02\n" +
+		"PC: expected $8000, actual $8002\n" +
+		"Cycles: expected 0, actual 7\n" +
+		"Flags.Z: expected $00, actual $01"
+	if tst.errs[0].(string) != expectedMsg {
+		t.Errorf("unexpected message: %s", tst.errs[0])
+	}
+
+	tst = &errorCapture{}
+	StateEqual(tst, expected, 1)
+	if !tst.failed {
+		t.Error("StateEqual failed for different types")
+	}
+
+	tst = &errorCapture{}
+	StateEqual(tst, expected, (*testState)(nil))
+	if !tst.failed {
+		t.Error("StateEqual failed for nil pointer")
+	}
+}
+
+// opaque is a struct type without exported fields.
+type opaque struct {
+	value int
+}
+
+type opaqueState struct {
+	A      uint8
+	Opaque opaque
+	Time   time.Time
+}
+
+func TestStateEqualUnexportedStructs(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := opaqueState{A: 1, Opaque: opaque{value: 1}, Time: now}
+
+	tst := &errorCapture{}
+	StateEqual(tst, expected, opaqueState{A: 1, Opaque: opaque{value: 1}, Time: now.In(time.FixedZone("x", 3600))})
+	if tst.failed {
+		t.Error("StateEqual failed for equal times in different locations")
+	}
+
+	tst = &errorCapture{}
+	StateEqual(tst, expected, opaqueState{A: 1, Opaque: opaque{value: 2}, Time: now.Add(time.Second)})
+	if !tst.failed {
+		t.Fatal("StateEqual did not detect differing structs without exported fields")
+	}
+	msg := tst.errs[0].(string)
+	if !strings.Contains(msg, "Opaque: expected {1}, actual {2}") || !strings.Contains(msg, "Time: expected") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+}
@@ -0,0 +1,201 @@
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// recordingQueueSize is the amount of frames that can be queued for the
+// recording worker before the rendering waits for the recorder.
+const recordingQueueSize = 16
+
+// frameCapture references the last rendered frame and the active recording.
+type frameCapture struct {
+	mu sync.Mutex
+
+	lastFrame *image.RGBA
+	recording *recording
+}
+
+// recording passes the frames to the recorder in a worker goroutine, which
+// keeps encoding and writing of the frames out of the render loop.
+type recording struct {
+	recorder FrameRecorder
+	start    time.Time
+	failed   atomic.Bool // set if recording a frame failed
+
+	mu     sync.Mutex // protects sending to and closing of the frames channel
+	closed bool
+	frames chan recordedFrame
+	done   chan struct{}
+	err    error // first error of the worker, valid after done is closed
+}
+
+type recordedFrame struct {
+	img       *image.RGBA
+	timestamp time.Duration
+}
+
+var capture = &frameCapture{}
+
+// PrepareFrame returns the image of the backend to render for the current
+// frame. It has to be called once per frame by the GUI renderers, it composes
+// the debug overlay and passes the frame to the screenshot and recording
+// functionality. Backends that implement FrameUpdater get updated first,
+// frames of backends that implement IndexedBackend are converted to RGBA.
+func PrepareFrame(backend Backend) (*image.RGBA, error) {
+	if err := updateFrames(backend); err != nil {
+		return nil, err
+	}
+
+	img := frameImage(backend)
+	capture.frame(img)
+	return DebugOverlay().Compose(img), nil
+}
+
+// CaptureScreenshot saves the last rendered frame as PNG image to the given
+// path. The frame is copied when this function is called, the debug overlay
+// is not part of the screenshot.
+func CaptureScreenshot(path string) error {
+	capture.mu.Lock()
+	var img *image.RGBA
+	if capture.lastFrame != nil {
+		img = cloneImage(capture.lastFrame)
+	}
+	capture.mu.Unlock()
+
+	if img == nil {
+		return errors.New("no frame has been rendered yet")
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating screenshot file: %w", err)
+	}
+
+	if err := png.Encode(f, img); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("encoding screenshot: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing screenshot file: %w", err)
+	}
+	return nil
+}
+
+// StartRecording starts passing every rendered frame to the given recorder.
+// The frames are recorded in the background, if recording a frame fails the
+// recording stops and the error is returned by StopRecording. A previously
+// active recording gets stopped, if that fails the new recording is not
+// started.
+func StartRecording(recorder FrameRecorder) error {
+	if err := StopRecording(); err != nil {
+		return err
+	}
+
+	r := &recording{
+		recorder: recorder,
+		start:    time.Now(),
+		frames:   make(chan recordedFrame, recordingQueueSize),
+		done:     make(chan struct{}),
+	}
+	go r.run()
+
+	capture.mu.Lock()
+	capture.recording = r
+	capture.mu.Unlock()
+	return nil
+}
+
+// StopRecording stops the active recording, waits for all queued frames to
+// be recorded and closes the recorder. It returns the first error that
+// occurred while recording.
+func StopRecording() error {
+	capture.mu.Lock()
+	r := capture.recording
+	capture.recording = nil
+	capture.mu.Unlock()
+
+	if r == nil {
+		return nil
+	}
+	return r.stop()
+}
+
+// frame keeps a reference to the frame for screenshots and queues a copy
+// for the active recording. The frame is only copied while recording, a
+// screenshot copies the referenced frame on demand.
+func (c *frameCapture) frame(img *image.RGBA) {
+	c.mu.Lock()
+	r := c.recording
+	record := r != nil && !r.failed.Load()
+	if record {
+		img = cloneImage(img)
+	}
+	c.lastFrame = img
+	c.mu.Unlock()
+
+	if record {
+		r.send(recordedFrame{
+			img:       img,
+			timestamp: time.Since(r.start),
+		})
+	}
+}
+
+// send queues the frame for recording, it waits if the queue is full.
+func (r *recording) send(frame recordedFrame) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.closed {
+		r.frames <- frame
+	}
+}
+
+// run records the queued frames until the queue is closed. After a failure
+// the recorder is closed and the remaining frames are discarded.
+func (r *recording) run() {
+	defer close(r.done)
+
+	for frame := range r.frames {
+		if r.failed.Load() {
+			continue
+		}
+		if err := r.recorder.RecordFrame(frame.img, frame.timestamp); err != nil {
+			r.failed.Store(true)
+			r.err = fmt.Errorf("recording frame: %w", err)
+			_ = r.recorder.Close()
+		}
+	}
+
+	if !r.failed.Load() {
+		if err := r.recorder.Close(); err != nil {
+			r.err = fmt.Errorf("closing recorder: %w", err)
+		}
+	}
+}
+
+// stop closes the queue and waits for the worker to finish.
+func (r *recording) stop() error {
+	r.mu.Lock()
+	r.closed = true
+	close(r.frames)
+	r.mu.Unlock()
+
+	<-r.done
+	return r.err
+}
+
+// cloneImage returns a copy of the image.
+func cloneImage(img *image.RGBA) *image.RGBA {
+	clone := image.NewRGBA(img.Rect)
+	copy(clone.Pix, img.Pix)
+	return clone
+}
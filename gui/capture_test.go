@@ -0,0 +1,145 @@
+package gui
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/retroenv/retrogolib/assert"
+	"github.com/retroenv/retrogolib/input"
+)
+
+func TestCaptureScreenshot(t *testing.T) {
+	b := &testBackend{img: image.NewRGBA(image.Rect(0, 0, 4, 2))}
+	b.img.SetRGBA(1, 1, color.RGBA{R: 0xff, A: 0xff})
+
+	_, err := PrepareFrame(b)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "screenshot.png")
+	assert.NoError(t, CaptureScreenshot(path))
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	img, err := png.Decode(f)
+	assert.NoError(t, err)
+	assert.Equal(t, b.img.Rect, img.Bounds())
+	r, _, _, _ := img.At(1, 1).RGBA()
+	assert.Equal(t, 0xffff, r)
+}
+
+func TestCaptureFrameCopies(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	c := &frameCapture{}
+
+	c.frame(img)
+	assert.True(t, c.lastFrame == img, "frame copied without recording")
+
+	c.recording = &recording{frames: make(chan recordedFrame, 1)}
+	c.frame(img)
+	assert.True(t, c.lastFrame != img, "frame not copied while recording")
+	recorded := <-c.recording.frames
+	assert.True(t, recorded.img == c.lastFrame, "recorded frame copied twice")
+}
+
+func TestRawRecorder(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, StartRecording(NewRawRecorder(&buf)))
+
+	b := &testBackend{img: image.NewRGBA(image.Rect(0, 0, 2, 2))}
+	b.img.Pix[0] = 0x12
+	for range 2 {
+		_, err := PrepareFrame(b)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, StopRecording())
+
+	const frameSize = 16 + 2*2*4
+	data := buf.Bytes()
+	assert.Len(t, data, 2*frameSize)
+	assert.Equal(t, 2, binary.LittleEndian.Uint32(data[8:]))
+	assert.Equal(t, 2, binary.LittleEndian.Uint32(data[12:]))
+	assert.Equal(t, 0x12, data[16])
+}
+
+func TestPNGRecorder(t *testing.T) {
+	dir := t.TempDir()
+	recorder, err := NewPNGRecorder(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, StartRecording(recorder))
+
+	b := &testBackend{img: image.NewRGBA(image.Rect(0, 0, 2, 2))}
+	_, err = PrepareFrame(b)
+	assert.NoError(t, err)
+	assert.NoError(t, StopRecording())
+
+	_, err = os.Stat(filepath.Join(dir, "frame_000000.png"))
+	assert.NoError(t, err)
+	timestamps, err := os.ReadFile(filepath.Join(dir, "timestamps.txt"))
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(timestamps, []byte("frame_000000.png ")))
+}
+
+// failingRecorder fails to record every frame.
+type failingRecorder struct {
+	closed bool
+}
+
+func (r *failingRecorder) RecordFrame(*image.RGBA, time.Duration) error {
+	return errors.New("disk full")
+}
+
+func (r *failingRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestRecordingError(t *testing.T) {
+	recorder := &failingRecorder{}
+	assert.NoError(t, StartRecording(recorder))
+
+	b := &testBackend{img: image.NewRGBA(image.Rect(0, 0, 2, 2))}
+	for range 3 {
+		_, err := PrepareFrame(b)
+		assert.NoError(t, err)
+	}
+
+	assert.Error(t, StopRecording(), "recording frame: disk full")
+	assert.True(t, recorder.closed)
+	assert.NoError(t, StopRecording())
+}
+
+type testBackend struct {
+	img *image.RGBA
+}
+
+func (b *testBackend) Image() *image.RGBA {
+	return b.img
+}
+
+func (b *testBackend) Dimensions() Dimensions {
+	return Dimensions{
+		ScaleFactor: 1.0,
+		Height:      b.img.Rect.Dy(),
+		Width:       b.img.Rect.Dx(),
+	}
+}
+
+func (b *testBackend) WindowTitle() string {
+	return "unit-test"
+}
+
+func (b *testBackend) KeyDown(_ input.Key) {
+}
+
+func (b *testBackend) KeyUp(_ input.Key) {
+}
@@ -0,0 +1,129 @@
+package gui
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// FrameUpdater can optionally be implemented by the Backend to let the GUI
+// render loop drive the emulation. UpdateFrames is called before rendering
+// a frame with the amount of emulation frames to run, which is based on the
+// pause, single step and speed settings of the emulation control.
+type FrameUpdater interface {
+	UpdateFrames(frames int) error
+}
+
+// EmulationControl controls the emulation speed of backends that implement
+// the FrameUpdater interface, it allows debuggers to pause the emulation,
+// step single frames and run in slow or fast motion. All methods are safe
+// for concurrent use.
+type EmulationControl struct {
+	mu sync.Mutex
+
+	paused      bool
+	steps       int     // pending single frame steps while paused
+	speed       float64 // emulation frames per rendered frame
+	accumulated float64 // fractional frames carried over to the next rendered frame
+}
+
+var emulationControl = NewEmulationControl()
+
+// Control returns the emulation control that is used by the GUI renderer.
+func Control() *EmulationControl {
+	return emulationControl
+}
+
+// NewEmulationControl returns a new emulation control that runs at normal speed.
+func NewEmulationControl() *EmulationControl {
+	return &EmulationControl{
+		speed: 1,
+	}
+}
+
+// Pause pauses the emulation.
+func (c *EmulationControl) Pause() {
+	c.mu.Lock()
+	c.paused = true
+	c.mu.Unlock()
+}
+
+// Resume resumes a paused emulation and drops pending single steps.
+func (c *EmulationControl) Resume() {
+	c.mu.Lock()
+	c.paused = false
+	c.steps = 0
+	c.mu.Unlock()
+}
+
+// Paused returns whether the emulation is paused.
+func (c *EmulationControl) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// StepFrame pauses the emulation and runs a single frame with the next
+// rendered frame. Multiple calls before the next rendered frame add up.
+func (c *EmulationControl) StepFrame() {
+	c.mu.Lock()
+	c.paused = true
+	c.steps++
+	c.mu.Unlock()
+}
+
+// SetSpeed sets the speed multiplier of the emulation, for example 0.5 runs
+// an emulation frame every second rendered frame and 2 runs 2 emulation
+// frames per rendered frame. Values of 0 or less, NaN and infinity are ignored.
+func (c *EmulationControl) SetSpeed(multiplier float64) {
+	if !(multiplier > 0) || math.IsInf(multiplier, 0) {
+		return
+	}
+	c.mu.Lock()
+	c.speed = multiplier
+	c.accumulated = 0
+	c.mu.Unlock()
+}
+
+// Speed returns the speed multiplier of the emulation.
+func (c *EmulationControl) Speed() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.speed
+}
+
+// FramesToRun returns the amount of emulation frames to run for the next
+// rendered frame. It has to be called once per rendered frame.
+func (c *EmulationControl) FramesToRun() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.paused {
+		steps := c.steps
+		c.steps = 0
+		return steps
+	}
+
+	c.accumulated += c.speed
+	frames := int(c.accumulated)
+	c.accumulated -= float64(frames)
+	return frames
+}
+
+// updateFrames runs the emulation frames of the backend if it implements
+// the FrameUpdater interface.
+func updateFrames(backend Backend) error {
+	updater, ok := backend.(FrameUpdater)
+	if !ok {
+		return nil
+	}
+
+	frames := Control().FramesToRun()
+	if frames == 0 {
+		return nil
+	}
+	if err := updater.UpdateFrames(frames); err != nil {
+		return fmt.Errorf("updating frames: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package gui
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+type updatingBackend struct {
+	testBackend
+
+	frames int
+}
+
+func (b *updatingBackend) UpdateFrames(frames int) error {
+	b.frames += frames
+	return nil
+}
+
+func TestEmulationControl(t *testing.T) {
+	c := NewEmulationControl()
+	assert.Equal(t, 1, c.FramesToRun())
+
+	c.Pause()
+	assert.True(t, c.Paused())
+	assert.Equal(t, 0, c.FramesToRun())
+	c.StepFrame()
+	c.StepFrame()
+	assert.Equal(t, 2, c.FramesToRun())
+	assert.Equal(t, 0, c.FramesToRun())
+
+	c.Resume()
+	c.SetSpeed(0.5)
+	assert.Equal(t, 0, c.FramesToRun())
+	assert.Equal(t, 1, c.FramesToRun())
+
+	c.SetSpeed(2.5)
+	assert.Equal(t, 2, c.FramesToRun())
+	assert.Equal(t, 3, c.FramesToRun())
+
+	c.SetSpeed(0)
+	assert.Equal(t, 2.5, c.Speed())
+	c.SetSpeed(math.NaN())
+	c.SetSpeed(math.Inf(1))
+	c.SetSpeed(math.Inf(-1))
+	assert.Equal(t, 2.5, c.Speed())
+}
+
+func TestPrepareFrameUpdates(t *testing.T) {
+	b := &updatingBackend{testBackend: testBackend{img: image.NewRGBA(image.Rect(0, 0, 1, 1))}}
+	_, err := PrepareFrame(b)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, b.frames)
+
+	Control().Pause()
+	defer Control().Resume()
+	_, err = PrepareFrame(b)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, b.frames)
+}
@@ -0,0 +1,62 @@
+package gui
+
+// The following interfaces can optionally be implemented by the Backend to
+// get notified about window events by the GUI renderer.
+
+// ResizeHandler gets notified when the window size changes.
+type ResizeHandler interface {
+	// WindowResized is called with the new window size in pixels.
+	WindowResized(width, height int)
+}
+
+// FocusHandler gets notified when the window gains or loses the input focus,
+// which can be used to pause the emulation while the window is in background.
+type FocusHandler interface {
+	WindowFocusChanged(focused bool)
+}
+
+// FileDropHandler gets notified when a file is dropped on the window, which
+// can be used to load a ROM.
+type FileDropHandler interface {
+	FileDropped(path string)
+}
+
+// CloseHandler gets notified when the user requests to close the window.
+type CloseHandler interface {
+	// CloseRequested returns whether the window should be closed, returning
+	// false vetoes the request, for example to ask for saving first.
+	CloseRequested() bool
+}
+
+// NotifyWindowResized passes the new window size to the backend if it
+// implements the ResizeHandler interface.
+func NotifyWindowResized(backend Backend, width, height int) {
+	if handler, ok := backend.(ResizeHandler); ok {
+		handler.WindowResized(width, height)
+	}
+}
+
+// NotifyFocusChanged passes the focus state to the backend if it implements
+// the FocusHandler interface.
+func NotifyFocusChanged(backend Backend, focused bool) {
+	if handler, ok := backend.(FocusHandler); ok {
+		handler.WindowFocusChanged(focused)
+	}
+}
+
+// NotifyFileDropped passes the dropped file path to the backend if it
+// implements the FileDropHandler interface.
+func NotifyFileDropped(backend Backend, path string) {
+	if handler, ok := backend.(FileDropHandler); ok {
+		handler.FileDropped(path)
+	}
+}
+
+// RequestClose returns whether the window should be closed. If the backend
+// implements the CloseHandler interface, it can veto the request.
+func RequestClose(backend Backend) bool {
+	if handler, ok := backend.(CloseHandler); ok {
+		return handler.CloseRequested()
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package gui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+type eventBackend struct {
+	testBackend
+
+	width, height int
+	focused       bool
+	dropped       []string
+	allowClose    bool
+}
+
+func (b *eventBackend) WindowResized(width, height int) {
+	b.width = width
+	b.height = height
+}
+
+func (b *eventBackend) WindowFocusChanged(focused bool) {
+	b.focused = focused
+}
+
+func (b *eventBackend) FileDropped(path string) {
+	b.dropped = append(b.dropped, path)
+}
+
+func (b *eventBackend) CloseRequested() bool {
+	return b.allowClose
+}
+
+func TestWindowEvents(t *testing.T) {
+	b := &eventBackend{}
+
+	NotifyWindowResized(b, 640, 480)
+	NotifyFocusChanged(b, true)
+	NotifyFileDropped(b, "game.nes")
+	assert.Equal(t, 640, b.width)
+	assert.Equal(t, 480, b.height)
+	assert.True(t, b.focused)
+	assert.Equal(t, []string{"game.nes"}, b.dropped)
+
+	assert.False(t, RequestClose(b))
+	b.allowClose = true
+	assert.True(t, RequestClose(b))
+
+	// backends without event handlers are always closed
+	assert.True(t, RequestClose(&testBackend{img: image.NewRGBA(image.Rect(0, 0, 1, 1))}))
+}
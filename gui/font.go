@@ -0,0 +1,262 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	glyphWidth  = 3
+	glyphHeight = 5
+
+	// charWidth is the width of a character including the spacing.
+	charWidth = glyphWidth + 1
+	// lineHeight is the height of a line of text including the spacing.
+	lineHeight = glyphHeight + 2
+)
+
+// glyph contains the rows of a character, the highest of the 3 bits is the left pixel.
+type glyph [glyphHeight]byte
+
+// fontGlyphs contains the glyphs of the built-in bitmap font. Lowercase letters
+// are rendered using the uppercase glyphs.
+var fontGlyphs = map[rune]glyph{
+	' ':  {0b000, 0b000, 0b000, 0b000, 0b000},
+	'0':  {0b111, 0b101, 0b101, 0b101, 0b111},
+	'1':  {0b010, 0b110, 0b010, 0b010, 0b111},
+	'2':  {0b111, 0b001, 0b111, 0b100, 0b111},
+	'3':  {0b111, 0b001, 0b111, 0b001, 0b111},
+	'4':  {0b101, 0b101, 0b111, 0b001, 0b001},
+	'5':  {0b111, 0b100, 0b111, 0b001, 0b111},
+	'6':  {0b111, 0b100, 0b111, 0b101, 0b111},
+	'7':  {0b111, 0b001, 0b001, 0b001, 0b001},
+	'8':  {0b111, 0b101, 0b111, 0b101, 0b111},
+	'9':  {0b111, 0b101, 0b111, 0b001, 0b111},
+	'A':  {0b010, 0b101, 0b111, 0b101, 0b101},
+	'B':  {0b110, 0b101, 0b110, 0b101, 0b110},
+	'C':  {0b011, 0b100, 0b100, 0b100, 0b011},
+	'D':  {0b110, 0b101, 0b101, 0b101, 0b110},
+	'E':  {0b111, 0b100, 0b110, 0b100, 0b111},
+	'F':  {0b111, 0b100, 0b110, 0b100, 0b100},
+	'G':  {0b011, 0b100, 0b101, 0b101, 0b011},
+	'H':  {0b101, 0b101, 0b111, 0b101, 0b101},
+	'I':  {0b111, 0b010, 0b010, 0b010, 0b111},
+	'J':  {0b001, 0b001, 0b001, 0b101, 0b010},
+	'K':  {0b101, 0b101, 0b110, 0b101, 0b101},
+	'L':  {0b100, 0b100, 0b100, 0b100, 0b111},
+	'M':  {0b101, 0b111, 0b111, 0b101, 0b101},
+	'N':  {0b110, 0b101, 0b101, 0b101, 0b101},
+	'O':  {0b010, 0b101, 0b101, 0b101, 0b010},
+	'P':  {0b110, 0b101, 0b110, 0b100, 0b100},
+	'Q':  {0b010, 0b101, 0b101, 0b110, 0b011},
+	'R':  {0b110, 0b101, 0b110, 0b101, 0b101},
+	'S':  {0b011, 0b100, 0b010, 0b001, 0b110},
+	'T':  {0b111, 0b010, 0b010, 0b010, 0b010},
+	'U':  {0b101, 0b101, 0b101, 0b101, 0b111},
+	'V':  {0b101, 0b101, 0b101, 0b101, 0b010},
+	'W':  {0b101, 0b101, 0b111, 0b111, 0b101},
+	'X':  {0b101, 0b101, 0b010, 0b101, 0b101},
+	'Y':  {0b101, 0b101, 0b010, 0b010, 0b010},
+	'Z':  {0b111, 0b001, 0b010, 0b100, 0b111},
+	'.':  {0b000, 0b000, 0b000, 0b000, 0b010},
+	',':  {0b000, 0b000, 0b000, 0b010, 0b100},
+	':':  {0b000, 0b010, 0b000, 0b010, 0b000},
+	';':  {0b000, 0b010, 0b000, 0b010, 0b100},
+	'-':  {0b000, 0b000, 0b111, 0b000, 0b000},
+	'+':  {0b000, 0b010, 0b111, 0b010, 0b000},
+	'=':  {0b000, 0b111, 0b000, 0b111, 0b000},
+	'*':  {0b000, 0b101, 0b010, 0b101, 0b000},
+	'/':  {0b001, 0b001, 0b010, 0b100, 0b100},
+	'%':  {0b101, 0b001, 0b010, 0b100, 0b101},
+	'$':  {0b011, 0b110, 0b010, 0b011, 0b110},
+	'#':  {0b101, 0b111, 0b101, 0b111, 0b101},
+	'(':  {0b001, 0b010, 0b010, 0b010, 0b001},
+	')':  {0b100, 0b010, 0b010, 0b010, 0b100},
+	'[':  {0b011, 0b010, 0b010, 0b010, 0b011},
+	']':  {0b110, 0b010, 0b010, 0b010, 0b110},
+	'<':  {0b001, 0b010, 0b100, 0b010, 0b001},
+	'>':  {0b100, 0b010, 0b001, 0b010, 0b100},
+	'!':  {0b010, 0b010, 0b010, 0b000, 0b010},
+	'?':  {0b111, 0b001, 0b010, 0b000, 0b010},
+	'_':  {0b000, 0b000, 0b000, 0b000, 0b111},
+	'\'': {0b010, 0b010, 0b000, 0b000, 0b000},
+	'"':  {0b101, 0b101, 0b000, 0b000, 0b000},
+	'¡':  {0b010, 0b000, 0b010, 0b010, 0b010},
+	'¿':  {0b010, 0b000, 0b010, 0b100, 0b111},
+
+	// Cyrillic letters that do not look like a Latin letter
+	'Б': {0b111, 0b100, 0b110, 0b101, 0b110},
+	'Г': {0b111, 0b100, 0b100, 0b100, 0b100},
+	'Д': {0b011, 0b101, 0b101, 0b111, 0b101},
+	'Ж': {0b101, 0b111, 0b010, 0b111, 0b101},
+	'З': {0b110, 0b001, 0b010, 0b001, 0b110},
+	'И': {0b101, 0b101, 0b111, 0b110, 0b101},
+	'Й': {0b010, 0b101, 0b111, 0b110, 0b101},
+	'Л': {0b011, 0b101, 0b101, 0b101, 0b101},
+	'П': {0b111, 0b101, 0b101, 0b101, 0b101},
+	'Ф': {0b010, 0b111, 0b101, 0b111, 0b010},
+	'Ц': {0b101, 0b101, 0b101, 0b111, 0b001},
+	'Ч': {0b101, 0b101, 0b111, 0b001, 0b001},
+	'Ш': {0b101, 0b101, 0b111, 0b111, 0b111},
+	'Щ': {0b101, 0b111, 0b111, 0b111, 0b001},
+	'Ъ': {0b110, 0b010, 0b011, 0b010, 0b011},
+	'Ы': {0b101, 0b101, 0b111, 0b101, 0b111},
+	'Ь': {0b100, 0b100, 0b110, 0b101, 0b110},
+	'Э': {0b110, 0b001, 0b011, 0b001, 0b110},
+	'Ю': {0b101, 0b111, 0b111, 0b111, 0b101},
+	'Я': {0b011, 0b101, 0b011, 0b101, 0b101},
+
+	// Greek letters that do not look like a Latin or Cyrillic letter
+	'Δ': {0b010, 0b010, 0b101, 0b101, 0b111},
+	'Θ': {0b010, 0b101, 0b111, 0b101, 0b010},
+	'Λ': {0b010, 0b101, 0b101, 0b101, 0b101},
+	'Ξ': {0b111, 0b000, 0b111, 0b000, 0b111},
+	'Σ': {0b111, 0b100, 0b010, 0b100, 0b111},
+	'Ψ': {0b101, 0b101, 0b111, 0b010, 0b010},
+	'Ω': {0b010, 0b101, 0b101, 0b010, 0b111},
+}
+
+// fontFallbacks maps letters with diacritics, which can not be displayed in the
+// small glyph size, to the base letters. This keeps translated texts of Latin
+// script languages readable. Greek and Cyrillic letters that look like a
+// letter of another script share its glyph.
+var fontFallbacks = buildFontFallbacks(map[rune]string{
+	'A': "ÀÁÂÃÄÅĀĂĄàáâãäåāăąАΑΆ",
+	'B': "ВΒ",
+	'C': "ÇĆĈĊČçćĉċčС",
+	'D': "ĎĐďđ",
+	'E': "ÈÉÊËĒĔĖĘĚèéêëēĕėęěЕЁЄΕΈ",
+	'G': "ĜĞĠĢĝğġģ",
+	'H': "ĤĦĥħНΗΉ",
+	'I': "ÌÍÎÏĨĪĬĮİìíîïĩīĭįıІЇΙΊΪ",
+	'J': "ĴĵЈ",
+	'K': "ĶķКΚ",
+	'L': "ĹĻĽĿŁĺļľŀł",
+	'M': "МΜ",
+	'N': "ÑŃŅŇñńņňΝ",
+	'O': "ÒÓÔÕÖØŌŎŐòóôõöøōŏőОΟΌ",
+	'P': "РΡ",
+	'R': "ŔŖŘŕŗř",
+	'S': "ŚŜŞŠßśŝşšЅ",
+	'T': "ŢŤŦţťŧТΤ",
+	'U': "ÙÚÛÜŨŪŬŮŰŲùúûüũūŭůűų",
+	'W': "Ŵŵ",
+	'X': "ХΧ",
+	'Y': "ÝŶŸýÿŷУЎΥΎΫ",
+	'Z': "ŹŻŽźżžΖ",
+
+	'Г': "ҐΓ",
+	'П': "Π",
+	'Ф': "Φ",
+	'Ω': "Ώ",
+})
+
+func buildFontFallbacks(bases map[rune]string) map[rune]rune {
+	fallbacks := map[rune]rune{}
+	for base, letters := range bases {
+		for _, r := range letters {
+			fallbacks[r] = base
+		}
+	}
+	return fallbacks
+}
+
+// textStyle defines how text is drawn.
+type textStyle struct {
+	color      color.RGBA
+	background color.RGBA // blended using its alpha value
+	scale      int
+}
+
+// textWidth returns the width in pixels of the rendered text at the given scale.
+func textWidth(text string, scale int) int {
+	return utf8.RuneCountInString(text) * charWidth * scale
+}
+
+// fontMu protects the font glyphs against concurrent registration of glyphs.
+var fontMu sync.RWMutex
+
+// RegisterGlyph registers the glyph of a character for the built-in bitmap
+// font, which allows displaying characters of scripts that the font does
+// not cover. A glyph consists of 5 rows of 3 pixels, the highest of the 3
+// bits of a row is the left pixel. Lowercase letters are rendered using the
+// glyph of the uppercase letter.
+func RegisterGlyph(r rune, rows [glyphHeight]byte) {
+	fontMu.Lock()
+	fontGlyphs[unicode.ToUpper(r)] = rows
+	fontMu.Unlock()
+}
+
+// lookupGlyph returns the glyph for the character, letters with diacritics
+// are rendered as base letter and unsupported characters as question mark.
+func lookupGlyph(r rune) glyph {
+	fontMu.RLock()
+	defer fontMu.RUnlock()
+
+	r = unicode.ToUpper(r)
+	if g, ok := fontGlyphs[r]; ok {
+		return g
+	}
+	if base, ok := fontFallbacks[r]; ok {
+		if g, ok := fontGlyphs[base]; ok {
+			return g
+		}
+	}
+	return fontGlyphs['?']
+}
+
+// drawText draws the text to the image at the given position with a
+// background to keep it readable on top of any image content.
+func drawText(img *image.RGBA, x, y int, text string, style textStyle) {
+	scale := style.scale
+	blendRect(img, image.Rect(x-scale, y-scale, x+textWidth(text, scale), y+(glyphHeight+1)*scale),
+		style.background)
+
+	for _, r := range text {
+		g := lookupGlyph(r)
+		for row, bits := range g {
+			for col := range glyphWidth {
+				if bits&(0b100>>col) == 0 {
+					continue
+				}
+				pixel := image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale)
+				fillRect(img, pixel, style.color)
+			}
+		}
+		x += charWidth * scale
+	}
+}
+
+// fillRect sets the pixels of the image inside the given rectangle to the color.
+func fillRect(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	r = r.Intersect(img.Rect)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+// blendRect blends the color using its alpha value over the pixels of the
+// image inside the given rectangle.
+func blendRect(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	alpha := uint32(c.A)
+	if alpha == 0 {
+		return
+	}
+
+	r = r.Intersect(img.Rect)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			p := img.RGBAAt(x, y)
+			p.R = uint8((uint32(c.R)*alpha + uint32(p.R)*(0xff-alpha)) / 0xff)
+			p.G = uint8((uint32(c.G)*alpha + uint32(p.G)*(0xff-alpha)) / 0xff)
+			p.B = uint8((uint32(c.B)*alpha + uint32(p.B)*(0xff-alpha)) / 0xff)
+			p.A = uint8(alpha + uint32(p.A)*(0xff-alpha)/0xff)
+			img.SetRGBA(x, y, p)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 // Dimensions contains settings for the window dimensions of the rendered window.
 type Dimensions struct {
 	ScaleFactor float64
+	Scaling     Scaling // initial scaling options, can be changed at runtime using SetScaling
 
 	Height int
 	Width  int
@@ -0,0 +1,72 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"sync"
+)
+
+// IndexedBackend can optionally be implemented by the Backend to supply an
+// 8 bit palette indexed frame instead of an RGBA image, which suits palette
+// based systems like the NES. The frame gets converted to RGBA by the gui
+// layer using a lookup table, the Image function of the Backend is not used
+// for rendering frames if this interface is implemented.
+type IndexedBackend interface {
+	IndexedImage() *image.Paletted
+}
+
+// indexedConverter converts indexed frames into a reused RGBA image.
+type indexedConverter struct {
+	mu sync.Mutex
+
+	frame *image.RGBA
+	lut   [256][4]byte
+}
+
+var indexed = &indexedConverter{}
+
+// frameImage returns the image of the current frame of the backend.
+func frameImage(backend Backend) *image.RGBA {
+	provider, ok := backend.(IndexedBackend)
+	if !ok {
+		return backend.Image()
+	}
+
+	src := provider.IndexedImage()
+	indexed.mu.Lock()
+	defer indexed.mu.Unlock()
+
+	if indexed.frame == nil || indexed.frame.Rect != src.Rect {
+		indexed.frame = image.NewRGBA(src.Rect)
+	}
+	indexed.convert(indexed.frame, src)
+	return indexed.frame
+}
+
+// ConvertIndexed converts the palette indexed image into the RGBA image of
+// the same dimensions. Indexes outside of the palette are converted to
+// transparent black.
+func ConvertIndexed(dst *image.RGBA, src *image.Paletted) {
+	var c indexedConverter
+	c.convert(dst, src)
+}
+
+func (c *indexedConverter) convert(dst *image.RGBA, src *image.Paletted) {
+	c.lut = [256][4]byte{}
+	for i, col := range src.Palette {
+		if i == len(c.lut) {
+			break
+		}
+		rgba := color.RGBAModel.Convert(col).(color.RGBA)
+		c.lut[i] = [4]byte{rgba.R, rgba.G, rgba.B, rgba.A}
+	}
+
+	width := src.Rect.Dx()
+	for y := range src.Rect.Dy() {
+		srcRow := src.Pix[y*src.Stride : y*src.Stride+width]
+		dstRow := dst.Pix[y*dst.Stride : y*dst.Stride+width*4]
+		for x, index := range srcRow {
+			copy(dstRow[x*4:x*4+4], c.lut[index][:])
+		}
+	}
+}
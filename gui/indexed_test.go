@@ -0,0 +1,53 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+type indexedBackend struct {
+	testBackend
+
+	frame *image.Paletted
+}
+
+func (b *indexedBackend) IndexedImage() *image.Paletted {
+	return b.frame
+}
+
+func TestConvertIndexed(t *testing.T) {
+	palette := color.Palette{
+		color.RGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xff},
+		color.RGBA{R: 0xff, A: 0xff},
+	}
+	src := image.NewPaletted(image.Rect(0, 0, 3, 2), palette)
+	src.SetColorIndex(1, 0, 1)
+	src.SetColorIndex(2, 1, 5) // outside of the palette
+
+	dst := image.NewRGBA(src.Rect)
+	ConvertIndexed(dst, src)
+	assert.Equal(t, palette[0], dst.RGBAAt(0, 0))
+	assert.Equal(t, palette[1], dst.RGBAAt(1, 0))
+	assert.Equal(t, color.RGBA{}, dst.RGBAAt(2, 1))
+}
+
+func TestPrepareFrameIndexed(t *testing.T) {
+	src := image.NewPaletted(image.Rect(0, 0, 4, 4), color.Palette{color.RGBA{G: 0x80, A: 0xff}})
+	b := &indexedBackend{frame: src}
+
+	img, err := PrepareFrame(b)
+	assert.NoError(t, err)
+	assert.Equal(t, src.Rect, img.Rect)
+	assert.Equal(t, color.RGBA{G: 0x80, A: 0xff}, img.RGBAAt(3, 3))
+}
+
+func BenchmarkConvertIndexed(b *testing.B) {
+	src := image.NewPaletted(image.Rect(0, 0, 256, 240), color.Palette{color.Black, color.White})
+	dst := image.NewRGBA(src.Rect)
+	for range b.N {
+		ConvertIndexed(dst, src)
+	}
+}
@@ -0,0 +1,140 @@
+package gui
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxLatencySamples is the number of latest latency samples that the
+// statistics are calculated from.
+const maxLatencySamples = 1024
+
+// LatencyStats contains statistics of the measured input latencies.
+type LatencyStats struct {
+	Count int // number of samples that the statistics are based on
+
+	Min  time.Duration
+	Max  time.Duration
+	Mean time.Duration
+	P50  time.Duration // median
+	P95  time.Duration
+	P99  time.Duration
+}
+
+// String returns the statistics formatted for logging.
+func (s LatencyStats) String() string {
+	return fmt.Sprintf("count=%d min=%s mean=%s p50=%s p95=%s p99=%s max=%s",
+		s.Count, s.Min, s.Mean, s.P50, s.P95, s.P99, s.Max)
+}
+
+// LatencyMonitor measures the time between receiving an input event from the
+// operating system and presenting the first frame that was rendered after
+// the event was processed. All methods are safe for concurrent use.
+type LatencyMonitor struct {
+	mu sync.Mutex
+
+	enabled bool
+	pending []time.Time // receipt times of inputs that wait for a frame
+
+	samples []time.Duration // ring buffer of the latest samples
+	next    int             // index of the next sample to write once the buffer is full
+}
+
+var inputLatency = &LatencyMonitor{}
+
+// InputLatency returns the input latency monitor that is used by the GUI renderer.
+func InputLatency() *LatencyMonitor {
+	return inputLatency
+}
+
+// SetEnabled enables or disables the latency measurement. Disabling it
+// drops inputs that are waiting for a frame but keeps the samples.
+func (m *LatencyMonitor) SetEnabled(enabled bool) {
+	m.mu.Lock()
+	m.enabled = enabled
+	m.pending = m.pending[:0]
+	m.mu.Unlock()
+}
+
+// Enabled returns whether the latency measurement is enabled.
+func (m *LatencyMonitor) Enabled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.enabled
+}
+
+// InputReceived records the time that an input event was received from the
+// operating system. It is called by the GUI renderers.
+func (m *LatencyMonitor) InputReceived(received time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.enabled {
+		m.pending = append(m.pending, received)
+	}
+}
+
+// FramePresented records a sample for every pending input event using the
+// given time of the frame present. It is called by the GUI renderers.
+func (m *LatencyMonitor) FramePresented(presented time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, received := range m.pending {
+		m.addSample(presented.Sub(received))
+	}
+	m.pending = m.pending[:0]
+}
+
+// Stats returns the statistics of the latest measured samples.
+func (m *LatencyMonitor) Stats() LatencyStats {
+	m.mu.Lock()
+	samples := slices.Clone(m.samples)
+	m.mu.Unlock()
+
+	if len(samples) == 0 {
+		return LatencyStats{}
+	}
+	slices.Sort(samples)
+
+	var sum time.Duration
+	for _, sample := range samples {
+		sum += sample
+	}
+
+	return LatencyStats{
+		Count: len(samples),
+		Min:   samples[0],
+		Max:   samples[len(samples)-1],
+		Mean:  sum / time.Duration(len(samples)),
+		P50:   percentile(samples, 50),
+		P95:   percentile(samples, 95),
+		P99:   percentile(samples, 99),
+	}
+}
+
+// Reset removes all measured samples.
+func (m *LatencyMonitor) Reset() {
+	m.mu.Lock()
+	m.pending = m.pending[:0]
+	m.samples = m.samples[:0]
+	m.next = 0
+	m.mu.Unlock()
+}
+
+func (m *LatencyMonitor) addSample(latency time.Duration) {
+	if len(m.samples) < maxLatencySamples {
+		m.samples = append(m.samples, latency)
+		return
+	}
+	m.samples[m.next] = latency
+	m.next = (m.next + 1) % maxLatencySamples
+}
+
+// percentile returns the nearest rank percentile of the sorted samples.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
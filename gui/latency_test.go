@@ -0,0 +1,55 @@
+package gui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestLatencyMonitor(t *testing.T) {
+	m := &LatencyMonitor{}
+	start := time.Now()
+
+	m.InputReceived(start)
+	m.FramePresented(start.Add(time.Millisecond))
+	assert.Equal(t, 0, m.Stats().Count, "disabled monitor recorded a sample")
+
+	m.SetEnabled(true)
+	for i := 1; i <= 100; i++ {
+		m.InputReceived(start)
+		m.FramePresented(start.Add(time.Duration(i) * time.Millisecond))
+	}
+
+	// frame without pending input does not add a sample
+	m.FramePresented(start.Add(time.Second))
+
+	stats := m.Stats()
+	assert.Equal(t, 100, stats.Count)
+	assert.Equal(t, time.Millisecond, stats.Min)
+	assert.Equal(t, 100*time.Millisecond, stats.Max)
+	assert.Equal(t, 50500*time.Microsecond, stats.Mean)
+	assert.Equal(t, 50*time.Millisecond, stats.P50)
+	assert.Equal(t, 95*time.Millisecond, stats.P95)
+	assert.Equal(t, 99*time.Millisecond, stats.P99)
+
+	m.Reset()
+	assert.Equal(t, LatencyStats{}, m.Stats())
+}
+
+func TestLatencyMonitorSampleLimit(t *testing.T) {
+	m := &LatencyMonitor{}
+	m.SetEnabled(true)
+	start := time.Now()
+
+	for range maxLatencySamples {
+		m.InputReceived(start)
+	}
+	m.FramePresented(start.Add(time.Second))
+	m.InputReceived(start)
+	m.FramePresented(start.Add(time.Millisecond))
+
+	stats := m.Stats()
+	assert.Equal(t, maxLatencySamples, stats.Count)
+	assert.Equal(t, time.Millisecond, stats.Min)
+}
@@ -0,0 +1,129 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale of the built-in messages, it is used as fallback
+// for messages that are missing in the catalog of the selected locale.
+const DefaultLocale = "en"
+
+// Message IDs of the built-in texts of the GUI.
+const (
+	MessageFPS      = "overlay.fps"       // argument: frames per second as float64
+	MessageCPUUsage = "overlay.cpu_usage" // argument: CPU usage percentage as float64
+)
+
+// Catalog maps message IDs to translated texts. Texts can contain fmt verbs
+// that get replaced by the arguments passed to Translate.
+type Catalog map[string]string
+
+var defaultCatalog = Catalog{
+	MessageFPS:      "FPS %.1f",
+	MessageCPUUsage: "CPU %.0f%%",
+}
+
+var localization = struct {
+	mu       sync.RWMutex
+	locale   string
+	catalogs map[string]Catalog
+}{
+	locale: DefaultLocale,
+	catalogs: map[string]Catalog{
+		DefaultLocale: defaultCatalog,
+	},
+}
+
+// RegisterCatalog registers the message catalog for the locale. Messages of
+// an already registered catalog of the same locale are replaced or extended.
+// Locales are in the format "language" or "language-region" like "de" or "pt-BR".
+// The built-in bitmap font covers Latin, Greek and Cyrillic letters, other
+// characters are rendered as question mark unless their glyphs are
+// registered using RegisterGlyph.
+func RegisterCatalog(locale string, catalog Catalog) {
+	locale = normalizeLocale(locale)
+
+	localization.mu.Lock()
+	defer localization.mu.Unlock()
+
+	existing, ok := localization.catalogs[locale]
+	if !ok {
+		existing = Catalog{}
+		localization.catalogs[locale] = existing
+	}
+	for id, text := range catalog {
+		existing[id] = text
+	}
+}
+
+// SetLocale sets the locale that is used for translating messages.
+func SetLocale(locale string) {
+	localization.mu.Lock()
+	localization.locale = normalizeLocale(locale)
+	localization.mu.Unlock()
+}
+
+// Locale returns the locale that is used for translating messages.
+func Locale() string {
+	localization.mu.RLock()
+	defer localization.mu.RUnlock()
+	return localization.locale
+}
+
+// EnvironmentLocale returns the locale that is configured by the LC_ALL,
+// LC_MESSAGES or LANG environment variables, or the default locale if none is set.
+func EnvironmentLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value != "" && value != "C" && value != "POSIX" {
+			return normalizeLocale(value)
+		}
+	}
+	return DefaultLocale
+}
+
+// Translate returns the text of the message in the current locale, formatted
+// with the arguments. If the message is missing in the catalog of the locale,
+// the catalog of the language without region and then the default catalog are
+// used. If the message is not found at all, the message ID is returned.
+func Translate(id string, args ...any) string {
+	localization.mu.RLock()
+	text, ok := lookupMessage(localization.locale, id)
+	localization.mu.RUnlock()
+
+	if !ok {
+		return id
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// lookupMessage returns the text of the message for the locale using the
+// fallback chain. The caller has to hold the localization lock.
+func lookupMessage(locale, id string) (string, bool) {
+	candidates := []string{locale}
+	if language, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, language)
+	}
+	candidates = append(candidates, DefaultLocale)
+
+	for _, candidate := range candidates {
+		if text, ok := localization.catalogs[candidate][id]; ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// normalizeLocale converts a locale like "pt_BR.UTF-8" to the format "pt-br".
+func normalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	locale = strings.ReplaceAll(locale, "_", "-")
+	return strings.ToLower(locale)
+}
@@ -0,0 +1,63 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+
+	RegisterCatalog("de", Catalog{
+		MessageFPS:  "BPS %.1f",
+		"menu.save": "Speichern",
+	})
+	RegisterCatalog("de_AT", Catalog{
+		"menu.save": "Sichern",
+	})
+
+	assert.Equal(t, "FPS 60.0", Translate(MessageFPS, 60.0))
+
+	SetLocale("de_AT.UTF-8")
+	assert.Equal(t, "de-at", Locale())
+	assert.Equal(t, "Sichern", Translate("menu.save"))
+	assert.Equal(t, "BPS 60.0", Translate(MessageFPS, 60.0))
+	assert.Equal(t, "CPU 50%", Translate(MessageCPUUsage, 50.0))
+	assert.Equal(t, "menu.unknown", Translate("menu.unknown"))
+}
+
+func TestEnvironmentLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "C")
+	t.Setenv("LANG", "pt_BR.UTF-8")
+	assert.Equal(t, "pt-br", EnvironmentLocale())
+}
+
+func TestLookupGlyphFallback(t *testing.T) {
+	assert.Equal(t, fontGlyphs['E'], lookupGlyph('é'))
+	assert.Equal(t, fontGlyphs['U'], lookupGlyph('Ü'))
+	assert.Equal(t, fontGlyphs['?'], lookupGlyph('€'))
+}
+
+func TestLookupGlyphGreekCyrillic(t *testing.T) {
+	assert.Equal(t, fontGlyphs['Ж'], lookupGlyph('ж'))
+	assert.Equal(t, fontGlyphs['A'], lookupGlyph('а'))
+	assert.Equal(t, fontGlyphs['Σ'], lookupGlyph('ς'))
+	assert.Equal(t, fontGlyphs['Ω'], lookupGlyph('ώ'))
+	assert.Equal(t, fontGlyphs['Г'], lookupGlyph('γ'))
+}
+
+func TestRegisterGlyph(t *testing.T) {
+	const r = 'ℵ'
+	defer func() {
+		fontMu.Lock()
+		delete(fontGlyphs, r)
+		fontMu.Unlock()
+	}()
+
+	assert.Equal(t, fontGlyphs['?'], lookupGlyph(r))
+	rows := [glyphHeight]byte{0b101, 0b101, 0b010, 0b101, 0b101}
+	RegisterGlyph(r, rows)
+	assert.Equal(t, glyph(rows), lookupGlyph(r))
+}
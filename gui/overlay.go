@@ -0,0 +1,183 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"time"
+)
+
+// overlayMargin is the distance in pixels of the overlay text to the image borders.
+const overlayMargin = 2
+
+// Register is a CPU register or other named value that is shown in the
+// register panel of the debug overlay.
+type Register struct {
+	Name  string
+	Value uint64
+	Size  int // size of the value in bytes, used for formatting
+}
+
+// String returns the register formatted for displaying in the overlay.
+func (r Register) String() string {
+	digits := max(r.Size, 1) * 2
+	return fmt.Sprintf("%s:%0*X", r.Name, digits, r.Value)
+}
+
+// Overlay is an on-screen debug overlay that gets composited over the image
+// of the backend before rendering. The image of the backend is not modified.
+// All methods are safe for concurrent use.
+type Overlay struct {
+	mu sync.Mutex
+
+	enabled   bool
+	lines     []string
+	registers []Register
+	cpuUsage  float64 // negative if not set
+
+	now         func() time.Time
+	frames      int
+	fps         float64
+	measureFrom time.Time
+
+	buffer *image.RGBA
+}
+
+var debugOverlay = NewOverlay()
+
+// DebugOverlay returns the debug overlay that is used by the GUI renderer.
+func DebugOverlay() *Overlay {
+	return debugOverlay
+}
+
+// NewOverlay returns a new disabled overlay.
+func NewOverlay() *Overlay {
+	return &Overlay{
+		cpuUsage: -1,
+		now:      time.Now,
+	}
+}
+
+// SetEnabled enables or disables the overlay.
+func (o *Overlay) SetEnabled(enabled bool) {
+	o.mu.Lock()
+	o.enabled = enabled
+	o.mu.Unlock()
+}
+
+// Toggle switches the overlay between enabled and disabled state.
+func (o *Overlay) Toggle() {
+	o.mu.Lock()
+	o.enabled = !o.enabled
+	o.mu.Unlock()
+}
+
+// Enabled returns whether the overlay is enabled.
+func (o *Overlay) Enabled() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.enabled
+}
+
+// SetLines sets custom text lines that are shown below the FPS counter.
+func (o *Overlay) SetLines(lines ...string) {
+	o.mu.Lock()
+	o.lines = append(o.lines[:0], lines...)
+	o.mu.Unlock()
+}
+
+// SetRegisters sets the registers that are shown in the register panel.
+func (o *Overlay) SetRegisters(registers ...Register) {
+	o.mu.Lock()
+	o.registers = append(o.registers[:0], registers...)
+	o.mu.Unlock()
+}
+
+// SetCPUUsage sets the CPU usage in percent that is shown below the FPS counter.
+func (o *Overlay) SetCPUUsage(percent float64) {
+	o.mu.Lock()
+	o.cpuUsage = percent
+	o.mu.Unlock()
+}
+
+// FPS returns the measured frames per second.
+func (o *Overlay) FPS() float64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.fps
+}
+
+// Compose counts the rendered frame and returns the image to render. If the
+// overlay is disabled the passed image is returned, otherwise a copy of the
+// image with the overlay drawn on top of it.
+func (o *Overlay) Compose(img *image.RGBA) *image.RGBA {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.countFrame()
+	if !o.enabled {
+		return img
+	}
+
+	if o.buffer == nil || o.buffer.Rect != img.Rect {
+		o.buffer = image.NewRGBA(img.Rect)
+	}
+	copy(o.buffer.Pix, img.Pix)
+
+	theme := CurrentTheme()
+	o.drawStatus(theme)
+	o.drawRegisters(theme)
+	return o.buffer
+}
+
+// countFrame updates the FPS measurement.
+func (o *Overlay) countFrame() {
+	now := o.now()
+	if o.measureFrom.IsZero() {
+		o.measureFrom = now
+		return
+	}
+
+	o.frames++
+	elapsed := now.Sub(o.measureFrom)
+	if elapsed < time.Second {
+		return
+	}
+
+	o.fps = float64(o.frames) / elapsed.Seconds()
+	o.frames = 0
+	o.measureFrom = now
+}
+
+// drawStatus draws the FPS counter, CPU usage and custom lines in the top left corner.
+func (o *Overlay) drawStatus(theme Theme) {
+	style := theme.textStyle(theme.Text)
+	x := o.buffer.Rect.Min.X + overlayMargin*style.scale
+	y := o.buffer.Rect.Min.Y + overlayMargin*style.scale
+
+	drawText(o.buffer, x, y, Translate(MessageFPS, o.fps), style)
+	y += lineHeight * style.scale
+
+	if o.cpuUsage >= 0 {
+		drawText(o.buffer, x, y, Translate(MessageCPUUsage, o.cpuUsage), style)
+		y += lineHeight * style.scale
+	}
+
+	for _, line := range o.lines {
+		drawText(o.buffer, x, y, line, style)
+		y += lineHeight * style.scale
+	}
+}
+
+// drawRegisters draws the register panel in the top right corner.
+func (o *Overlay) drawRegisters(theme Theme) {
+	style := theme.textStyle(theme.Accent)
+	y := o.buffer.Rect.Min.Y + overlayMargin*style.scale
+
+	for _, reg := range o.registers {
+		text := reg.String()
+		x := o.buffer.Rect.Max.X - overlayMargin*style.scale - textWidth(text, style.scale)
+		drawText(o.buffer, x, y, text, style)
+		y += lineHeight * style.scale
+	}
+}
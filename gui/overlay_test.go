@@ -0,0 +1,52 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestOverlayCompose(t *testing.T) {
+	o := NewOverlay()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+
+	assert.Equal(t, img, o.Compose(img))
+
+	o.SetEnabled(true)
+	o.SetLines("HELLO")
+	o.SetRegisters(Register{Name: "A", Value: 0x1f, Size: 1})
+
+	composed := o.Compose(img)
+	assert.NotEqual(t, img, composed)
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(overlayMargin, overlayMargin), "backend image modified")
+
+	// first pixel of the F of the FPS counter
+	assert.Equal(t, DefaultTheme.Text, composed.RGBAAt(overlayMargin, overlayMargin))
+
+	// first pixel of the F of the register value A:1F in the top right corner
+	x := 64 - overlayMargin - charWidth
+	assert.Equal(t, DefaultTheme.Accent, composed.RGBAAt(x, overlayMargin))
+}
+
+func TestOverlayFPS(t *testing.T) {
+	o := NewOverlay()
+	now := time.Now()
+	o.now = func() time.Time {
+		return now
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	for range 62 {
+		o.Compose(img)
+		now = now.Add(time.Second / 60)
+	}
+	assert.Equal(t, 60, int(o.FPS()+0.5))
+}
+
+func TestRegisterString(t *testing.T) {
+	assert.Equal(t, "PC:C000", Register{Name: "PC", Value: 0xc000, Size: 2}.String())
+	assert.Equal(t, "A:0F", Register{Name: "A", Value: 0x0f}.String())
+}
@@ -0,0 +1,139 @@
+package gui
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FrameRecorder records rendered frames.
+type FrameRecorder interface {
+	// RecordFrame records a frame, the timestamp is relative to the start of the recording.
+	// The image is only valid during the call.
+	RecordFrame(img *image.RGBA, timestamp time.Duration) error
+	// Close finishes the recording.
+	Close() error
+}
+
+// PNGRecorder records frames as a sequence of PNG images in a directory.
+// The timestamps of the frames are written to a timestamps.txt file in the
+// same directory, every line contains the file name and the timestamp in
+// nanoseconds.
+type PNGRecorder struct {
+	dir        string
+	frame      int
+	timestamps *os.File
+}
+
+// NewPNGRecorder returns a new PNG sequence recorder that writes to the given directory.
+// The directory is created if it does not exist.
+func NewPNGRecorder(dir string) (*PNGRecorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating recording directory: %w", err)
+	}
+
+	timestamps, err := os.Create(filepath.Join(dir, "timestamps.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("creating timestamps file: %w", err)
+	}
+
+	return &PNGRecorder{
+		dir:        dir,
+		timestamps: timestamps,
+	}, nil
+}
+
+// RecordFrame writes the frame as PNG image.
+func (r *PNGRecorder) RecordFrame(img *image.RGBA, timestamp time.Duration) error {
+	name := fmt.Sprintf("frame_%06d.png", r.frame)
+	r.frame++
+
+	f, err := os.Create(filepath.Join(r.dir, name))
+	if err != nil {
+		return fmt.Errorf("creating frame file: %w", err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("encoding frame: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing frame file: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(r.timestamps, "%s %d\n", name, timestamp.Nanoseconds()); err != nil {
+		return fmt.Errorf("writing timestamp: %w", err)
+	}
+	return nil
+}
+
+// Close closes the timestamps file.
+func (r *PNGRecorder) Close() error {
+	if err := r.timestamps.Close(); err != nil {
+		return fmt.Errorf("closing timestamps file: %w", err)
+	}
+	return nil
+}
+
+// RawRecorder records frames as raw RGBA dump. Every frame is written as
+// a header of the timestamp in nanoseconds (int64), the width and height
+// (uint32) in little endian byte order, followed by the RGBA pixel data.
+type RawRecorder struct {
+	closer io.Closer
+	writer *bufio.Writer
+}
+
+// NewRawRecorder returns a new raw RGBA recorder that writes to the given writer.
+// If the writer implements io.Closer, it gets closed when the recording is closed.
+func NewRawRecorder(w io.Writer) *RawRecorder {
+	r := &RawRecorder{
+		writer: bufio.NewWriter(w),
+	}
+	if closer, ok := w.(io.Closer); ok {
+		r.closer = closer
+	}
+	return r
+}
+
+// RecordFrame writes the frame header and pixel data.
+func (r *RawRecorder) RecordFrame(img *image.RGBA, timestamp time.Duration) error {
+	header := struct {
+		Timestamp int64
+		Width     uint32
+		Height    uint32
+	}{
+		Timestamp: timestamp.Nanoseconds(),
+		Width:     uint32(img.Rect.Dx()),
+		Height:    uint32(img.Rect.Dy()),
+	}
+	if err := binary.Write(r.writer, binary.LittleEndian, header); err != nil {
+		return fmt.Errorf("writing frame header: %w", err)
+	}
+
+	rowSize := img.Rect.Dx() * 4
+	for y := range img.Rect.Dy() {
+		offset := y * img.Stride
+		if _, err := r.writer.Write(img.Pix[offset : offset+rowSize]); err != nil {
+			return fmt.Errorf("writing frame data: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close flushes the buffered data and closes the writer if it is closable.
+func (r *RawRecorder) Close() error {
+	if err := r.writer.Flush(); err != nil {
+		return fmt.Errorf("flushing frame data: %w", err)
+	}
+	if r.closer != nil {
+		if err := r.closer.Close(); err != nil {
+			return fmt.Errorf("closing writer: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package remote
+
+import "github.com/retroenv/retrogolib/input"
+
+// keyMapping maps the browser KeyboardEvent.code values to input keys.
+var keyMapping = map[string]input.Key{
+	"Space":          input.Space,
+	"Quote":          input.Apostrophe,
+	"Comma":          input.Comma,
+	"Minus":          input.Minus,
+	"Period":         input.Period,
+	"Slash":          input.Slash,
+	"Digit0":         input.Key0,
+	"Digit1":         input.Key1,
+	"Digit2":         input.Key2,
+	"Digit3":         input.Key3,
+	"Digit4":         input.Key4,
+	"Digit5":         input.Key5,
+	"Digit6":         input.Key6,
+	"Digit7":         input.Key7,
+	"Digit8":         input.Key8,
+	"Digit9":         input.Key9,
+	"Semicolon":      input.Semicolon,
+	"Equal":          input.Equal,
+	"KeyA":           input.A,
+	"KeyB":           input.B,
+	"KeyC":           input.C,
+	"KeyD":           input.D,
+	"KeyE":           input.E,
+	"KeyF":           input.F,
+	"KeyG":           input.G,
+	"KeyH":           input.H,
+	"KeyI":           input.I,
+	"KeyJ":           input.J,
+	"KeyK":           input.K,
+	"KeyL":           input.L,
+	"KeyM":           input.M,
+	"KeyN":           input.N,
+	"KeyO":           input.O,
+	"KeyP":           input.P,
+	"KeyQ":           input.Q,
+	"KeyR":           input.R,
+	"KeyS":           input.S,
+	"KeyT":           input.T,
+	"KeyU":           input.U,
+	"KeyV":           input.V,
+	"KeyW":           input.W,
+	"KeyX":           input.X,
+	"KeyY":           input.Y,
+	"KeyZ":           input.Z,
+	"BracketLeft":    input.LeftBracket,
+	"Backslash":      input.Backslash,
+	"BracketRight":   input.RightBracket,
+	"Escape":         input.Escape,
+	"Enter":          input.Enter,
+	"Tab":            input.Tab,
+	"Backspace":      input.Backspace,
+	"Insert":         input.Insert,
+	"Delete":         input.Delete,
+	"ArrowRight":     input.Right,
+	"ArrowLeft":      input.Left,
+	"ArrowDown":      input.Down,
+	"ArrowUp":        input.Up,
+	"PageUp":         input.PageUp,
+	"PageDown":       input.PageDown,
+	"Home":           input.Home,
+	"End":            input.End,
+	"CapsLock":       input.CapsLock,
+	"ScrollLock":     input.ScrollLock,
+	"NumLock":        input.NumLock,
+	"PrintScreen":    input.PrintScreen,
+	"Pause":          input.Pause,
+	"F1":             input.F1,
+	"F2":             input.F2,
+	"F3":             input.F3,
+	"F4":             input.F4,
+	"F5":             input.F5,
+	"F6":             input.F6,
+	"F7":             input.F7,
+	"F8":             input.F8,
+	"F9":             input.F9,
+	"F10":            input.F10,
+	"F11":            input.F11,
+	"F12":            input.F12,
+	"Numpad0":        input.KP0,
+	"Numpad1":        input.KP1,
+	"Numpad2":        input.KP2,
+	"Numpad3":        input.KP3,
+	"Numpad4":        input.KP4,
+	"Numpad5":        input.KP5,
+	"Numpad6":        input.KP6,
+	"Numpad7":        input.KP7,
+	"Numpad8":        input.KP8,
+	"Numpad9":        input.KP9,
+	"NumpadDecimal":  input.KPDecimal,
+	"NumpadDivide":   input.KPDivide,
+	"NumpadMultiply": input.KPMultiply,
+	"NumpadSubtract": input.KPSubtract,
+	"NumpadAdd":      input.KPAdd,
+	"NumpadEnter":    input.KPEnter,
+	"NumpadEqual":    input.KPEqual,
+	"ShiftLeft":      input.LeftShift,
+	"ControlLeft":    input.LeftControl,
+	"AltLeft":        input.LeftAlt,
+	"MetaLeft":       input.LeftSuper,
+	"ShiftRight":     input.RightShift,
+	"ControlRight":   input.RightControl,
+	"AltRight":       input.RightAlt,
+	"MetaRight":      input.RightSuper,
+	"ContextMenu":    input.Menu,
+}
@@ -0,0 +1,39 @@
+package remote
+
+// page is the HTML page that displays the streamed frames and sends the
+// keyboard input back to the server.
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{title}}</title>
+<style>
+html, body { margin: 0; height: 100%; background: #000; }
+img { width: 100%; height: 100%; object-fit: contain; image-rendering: pixelated; }
+</style>
+</head>
+<body>
+<img id="screen" alt="">
+<script>
+const screen = document.getElementById("screen");
+const protocol = location.protocol === "https:" ? "wss://" : "ws://";
+const ws = new WebSocket(protocol + location.host + "/ws");
+ws.binaryType = "blob";
+ws.onmessage = (e) => {
+  const url = URL.createObjectURL(e.data);
+  screen.onload = () => URL.revokeObjectURL(url);
+  screen.src = url;
+};
+const send = (action, e) => {
+  if (e.repeat || ws.readyState !== WebSocket.OPEN) {
+    return;
+  }
+  ws.send(action + ":" + e.code);
+  e.preventDefault();
+};
+document.addEventListener("keydown", (e) => send("down", e));
+document.addEventListener("keyup", (e) => send("up", e));
+</script>
+</body>
+</html>
+`
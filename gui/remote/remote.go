@@ -0,0 +1,316 @@
+// Package remote provides a GUI renderer that streams the rendered frames to
+// a browser using WebSocket or MJPEG and accepts keyboard input back. It can
+// be used on headless servers and for remote debugging sessions.
+package remote
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"image/jpeg"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/retroenv/retrogolib/gui"
+	"github.com/retroenv/retrogolib/input"
+)
+
+const (
+	// DefaultAddress is the address that the HTTP server listens on if no address is set.
+	DefaultAddress = "localhost:8080"
+
+	defaultQuality    = 80
+	keyEventQueueSize = 64
+	mjpegBoundary     = "frame"
+)
+
+// Options contains options for the remote renderer.
+type Options struct {
+	Address string // listen address of the HTTP server, defaults to DefaultAddress
+	Quality int    // JPEG quality of the streamed frames in range 1-100, defaults to 80
+
+	// AllowedOrigins contains the origins like "http://example.com:8080"
+	// that are allowed to open a WebSocket connection in addition to the
+	// origin of the server itself, "*" allows all origins.
+	AllowedOrigins []string
+}
+
+// Setup starts the remote renderer using the default options and returns a render and cleanup function.
+func Setup(backend gui.Backend) (guiRender func() (bool, error), guiCleanup func(), err error) {
+	return New(Options{})(backend)
+}
+
+// New returns an initializer for the remote renderer that uses the given options.
+func New(opts Options) gui.Initializer {
+	return func(backend gui.Backend) (func() (bool, error), func(), error) {
+		s, err := newServer(backend, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s.render, s.cleanup, nil
+	}
+}
+
+type keyEvent struct {
+	key  input.Key
+	down bool
+}
+
+// client is a connected viewer that frames get streamed to.
+type client struct {
+	frames chan []byte
+	done   chan struct{}
+}
+
+type server struct {
+	backend        gui.Backend
+	quality        int
+	allowedOrigins []string
+	listener       net.Listener
+	httpServer     *http.Server
+
+	mu      sync.Mutex
+	clients map[*client]struct{}
+
+	keyEvents chan keyEvent
+	serveErr  chan error
+	buf       bytes.Buffer
+}
+
+func newServer(backend gui.Backend, opts Options) (*server, error) {
+	if opts.Address == "" {
+		opts.Address = DefaultAddress
+	}
+	if opts.Quality == 0 {
+		opts.Quality = defaultQuality
+	}
+
+	listener, err := net.Listen("tcp", opts.Address)
+	if err != nil {
+		return nil, fmt.Errorf("listening on '%s': %w", opts.Address, err)
+	}
+
+	s := &server{
+		backend:        backend,
+		quality:        opts.Quality,
+		allowedOrigins: opts.AllowedOrigins,
+		listener:       listener,
+		clients:        map[*client]struct{}{},
+		keyEvents:      make(chan keyEvent, keyEventQueueSize),
+		serveErr:       make(chan error, 1),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handlePage)
+	mux.HandleFunc("/ws", s.handleWebsocket)
+	mux.HandleFunc("/stream", s.handleMJPEG)
+	s.httpServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.serveErr <- err
+		}
+	}()
+
+	return s, nil
+}
+
+// render processes the received input events and streams the current frame
+// to all connected clients.
+func (s *server) render() (bool, error) {
+	select {
+	case err := <-s.serveErr:
+		return false, fmt.Errorf("serving HTTP: %w", err)
+	default:
+	}
+
+	s.processKeyEvents()
+
+	s.mu.Lock()
+	hasClients := len(s.clients) > 0
+	s.mu.Unlock()
+
+	img, err := gui.PrepareFrame(s.backend)
+	if err != nil {
+		return false, fmt.Errorf("preparing frame: %w", err)
+	}
+	if !hasClients {
+		return true, nil
+	}
+
+	s.buf.Reset()
+	if err := jpeg.Encode(&s.buf, img, &jpeg.Options{Quality: s.quality}); err != nil {
+		return false, fmt.Errorf("encoding frame: %w", err)
+	}
+	frame := bytes.Clone(s.buf.Bytes())
+
+	s.mu.Lock()
+	for c := range s.clients {
+		select {
+		case c.frames <- frame:
+		default: // drop the frame for slow clients
+		}
+	}
+	s.mu.Unlock()
+
+	return true, nil
+}
+
+// processKeyEvents forwards all queued key events to the backend.
+func (s *server) processKeyEvents() {
+	for {
+		select {
+		case ev := <-s.keyEvents:
+			if ev.down {
+				s.backend.KeyDown(ev.key)
+			} else {
+				s.backend.KeyUp(ev.key)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// cleanup disconnects all clients and stops the HTTP server.
+func (s *server) cleanup() {
+	s.mu.Lock()
+	for c := range s.clients {
+		close(c.done)
+		delete(s.clients, c)
+	}
+	s.mu.Unlock()
+
+	_ = s.httpServer.Close()
+}
+
+func (s *server) addClient() *client {
+	c := &client{
+		frames: make(chan []byte, 1),
+		done:   make(chan struct{}),
+	}
+	s.mu.Lock()
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+	return c
+}
+
+func (s *server) removeClient(c *client) {
+	s.mu.Lock()
+	if _, ok := s.clients[c]; ok {
+		close(c.done)
+		delete(s.clients, c)
+	}
+	s.mu.Unlock()
+}
+
+func (s *server) handlePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(strings.Replace(page, "{{title}}", html.EscapeString(s.backend.WindowTitle()), 1)))
+}
+
+// handleWebsocket streams frames as binary messages and reads key events
+// as text messages in the format "down:KeyA" or "up:KeyA".
+func (s *server) handleWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgradeWebsocket(w, r, s.allowedOrigins)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errOriginForbidden) {
+			status = http.StatusForbidden
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	c := s.addClient()
+	go func() {
+		for {
+			select {
+			case <-c.done:
+				_ = conn.Close()
+				return
+			case frame := <-c.frames:
+				if err := conn.WriteMessage(opcodeBinary, frame); err != nil {
+					s.removeClient(c)
+				}
+			}
+		}
+	}()
+
+	for {
+		opcode, payload, err := conn.ReadMessage()
+		if err != nil {
+			s.removeClient(c)
+			return
+		}
+		if opcode == opcodeText {
+			s.queueKeyEvent(string(payload))
+		}
+	}
+}
+
+// queueKeyEvent parses a key event message and queues it for processing
+// in the render loop. Unknown keys are ignored.
+func (s *server) queueKeyEvent(message string) {
+	action, code, ok := strings.Cut(message, ":")
+	if !ok {
+		return
+	}
+	key, ok := keyMapping[code]
+	if !ok {
+		return
+	}
+
+	ev := keyEvent{key: key, down: action == "down"}
+	select {
+	case s.keyEvents <- ev:
+	default: // drop the event if the render loop is not processing events
+	}
+}
+
+// handleMJPEG streams frames as multipart JPEG stream that is supported
+// by browsers and most video players.
+func (s *server) handleMJPEG(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary="+mjpegBoundary)
+	c := s.addClient()
+	defer s.removeClient(c)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-c.done:
+			return
+		case frame := <-c.frames:
+			header := fmt.Sprintf("--%s\r\nContent-Type: image/jpeg\r\nContent-Length: %d\r\n\r\n",
+				mjpegBoundary, len(frame))
+			if _, err := w.Write([]byte(header)); err != nil {
+				return
+			}
+			if _, err := w.Write(frame); err != nil {
+				return
+			}
+			if _, err := w.Write([]byte("\r\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
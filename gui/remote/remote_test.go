@@ -0,0 +1,159 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/binary"
+	"image"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/retroenv/retrogolib/assert"
+	"github.com/retroenv/retrogolib/gui"
+	"github.com/retroenv/retrogolib/input"
+)
+
+func TestAcceptKey(t *testing.T) {
+	// example from RFC 6455
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", acceptKey("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+func TestWebsocketStreaming(t *testing.T) {
+	b := &backend{}
+	s, err := newServer(b, Options{Address: "127.0.0.1:0"})
+	assert.NoError(t, err)
+	defer s.cleanup()
+
+	conn, err := net.Dial("tcp", s.listener.Addr().String())
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	request := "GET /ws HTTP/1.1\r\nHost: localhost\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n" +
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n\r\n"
+	_, err = conn.Write([]byte(request))
+	assert.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+
+	// send a masked key event from the client
+	mask := [4]byte{1, 2, 3, 4}
+	payload := []byte("down:KeyA")
+	frame := []byte{0x80 | opcodeText, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, c := range payload {
+		frame = append(frame, c^mask[i%4])
+	}
+	_, err = conn.Write(frame)
+	assert.NoError(t, err)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for b.pressedKey() != input.A && time.Now().Before(deadline) {
+		_, err := s.render()
+		assert.NoError(t, err)
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, input.A, b.pressedKey())
+
+	// read a streamed frame
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var header [2]byte
+	_, err = io.ReadFull(reader, header[:])
+	assert.NoError(t, err)
+	assert.Equal(t, 0x80|opcodeBinary, header[0])
+
+	var length uint64
+	switch header[1] {
+	case 126:
+		var ext [2]byte
+		_, err = io.ReadFull(reader, ext[:])
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		_, err = io.ReadFull(reader, ext[:])
+		length = binary.BigEndian.Uint64(ext[:])
+	default:
+		length = uint64(header[1])
+	}
+	assert.NoError(t, err)
+
+	data := make([]byte, length)
+	_, err = io.ReadFull(reader, data)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0xd8}, data[:2], "missing JPEG start of image marker")
+}
+
+func TestOriginAllowed(t *testing.T) {
+	r, err := http.NewRequest(http.MethodGet, "http://localhost:8080/ws", nil)
+	assert.NoError(t, err)
+	assert.True(t, originAllowed(r, nil))
+
+	r.Header.Set("Origin", "http://localhost:8080")
+	assert.True(t, originAllowed(r, nil))
+
+	r.Header.Set("Origin", "http://evil.example")
+	assert.False(t, originAllowed(r, nil))
+	assert.True(t, originAllowed(r, []string{"http://evil.example"}))
+	assert.True(t, originAllowed(r, []string{"*"}))
+}
+
+func TestWebsocketCrossOrigin(t *testing.T) {
+	s, err := newServer(&backend{}, Options{Address: "127.0.0.1:0"})
+	assert.NoError(t, err)
+	defer s.cleanup()
+
+	r, err := http.NewRequest(http.MethodGet, "http://"+s.listener.Addr().String()+"/ws", nil)
+	assert.NoError(t, err)
+	r.Header.Set("Connection", "Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Sec-WebSocket-Version", "13")
+	r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	r.Header.Set("Origin", "http://evil.example")
+
+	resp, err := http.DefaultClient.Do(r)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+type backend struct {
+	mu  sync.Mutex
+	key input.Key
+}
+
+func (b *backend) Image() *image.RGBA {
+	return image.NewRGBA(image.Rect(0, 0, 16, 16))
+}
+
+func (b *backend) Dimensions() gui.Dimensions {
+	return gui.Dimensions{
+		ScaleFactor: 1.0,
+		Height:      16,
+		Width:       16,
+	}
+}
+
+func (b *backend) WindowTitle() string {
+	return "unit-test"
+}
+
+func (b *backend) KeyDown(key input.Key) {
+	b.mu.Lock()
+	b.key = key
+	b.mu.Unlock()
+}
+
+func (b *backend) KeyUp(_ input.Key) {
+}
+
+func (b *backend) pressedKey() input.Key {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.key
+}
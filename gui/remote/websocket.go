@@ -0,0 +1,242 @@
+package remote
+
+import (
+	"bufio"
+	"crypto/sha1" // nolint: gosec // required by the WebSocket protocol
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// websocketGUID is the fixed GUID that is defined by RFC 6455 to calculate the handshake accept key.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxMessageSize is the maximum payload size of a message that is accepted from a client.
+const maxMessageSize = 4096
+
+// WebSocket frame opcodes.
+const (
+	opcodeText   = 0x1
+	opcodeBinary = 0x2
+	opcodeClose  = 0x8
+	opcodePing   = 0x9
+	opcodePong   = 0xa
+)
+
+var (
+	errClosed          = errors.New("websocket connection closed")
+	errOriginForbidden = errors.New("websocket origin not allowed")
+)
+
+// websocketConn is a minimal server side WebSocket connection that supports
+// unfragmented messages as defined by RFC 6455.
+type websocketConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// upgradeWebsocket performs the WebSocket handshake and takes over the
+// underlying connection of the HTTP request. Cross-origin requests are
+// rejected unless the origin is in the list of allowed origins.
+func upgradeWebsocket(w http.ResponseWriter, r *http.Request, allowedOrigins []string) (*websocketConn, error) {
+	if !headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") {
+		return nil, errors.New("missing websocket upgrade headers")
+	}
+	if !originAllowed(r, allowedOrigins) {
+		return nil, errOriginForbidden
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		return nil, errors.New("missing websocket key")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("connection does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("hijacking connection: %w", err)
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("writing handshake response: %w", err)
+	}
+
+	return &websocketConn{
+		conn:   conn,
+		reader: rw.Reader,
+	}, nil
+}
+
+// acceptKey returns the handshake accept key for the key sent by the client.
+func acceptKey(key string) string {
+	h := sha1.New() // nolint: gosec // required by the WebSocket protocol
+	h.Write([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// originAllowed returns whether the origin of the request matches the host
+// of the request or one of the allowed origins. Browsers always send the
+// origin, requests of other clients without it are allowed. This prevents
+// other web sites from connecting to the server through the browser of a
+// user and sending key events.
+func originAllowed(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if slices.Contains(allowedOrigins, "*") || slices.ContainsFunc(allowedOrigins, func(allowed string) bool {
+		return strings.EqualFold(allowed, origin)
+	}) {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// headerContains returns whether the comma separated header values contain the given token.
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, s := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(s), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Close closes the underlying connection.
+func (c *websocketConn) Close() error {
+	if err := c.conn.Close(); err != nil {
+		return fmt.Errorf("closing connection: %w", err)
+	}
+	return nil
+}
+
+// WriteMessage writes a single unfragmented message.
+func (c *websocketConn) WriteMessage(opcode byte, payload []byte) error {
+	header := make([]byte, 2, 10)
+	header[0] = 0x80 | opcode // FIN bit set
+
+	length := len(payload)
+	switch {
+	case length < 126:
+		header[1] = byte(length)
+	case length <= 0xffff:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+	return nil
+}
+
+// ReadMessage reads the next text or binary message, control frames are
+// handled internally.
+func (c *websocketConn) ReadMessage() (byte, []byte, error) {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case opcodeText, opcodeBinary:
+			return opcode, payload, nil
+		case opcodePing:
+			if err := c.WriteMessage(opcodePong, payload); err != nil {
+				return 0, nil, err
+			}
+		case opcodePong:
+		case opcodeClose:
+			_ = c.WriteMessage(opcodeClose, nil)
+			return 0, nil, errClosed
+		default:
+			return 0, nil, fmt.Errorf("unsupported websocket opcode %d", opcode)
+		}
+	}
+}
+
+// readFrame reads a single frame and unmasks the payload.
+func (c *websocketConn) readFrame() (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return 0, nil, fmt.Errorf("reading frame header: %w", err)
+	}
+
+	if header[0]&0x80 == 0 {
+		return 0, nil, errors.New("fragmented websocket messages are not supported")
+	}
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	if !masked {
+		return 0, nil, errors.New("client websocket frame is not masked")
+	}
+
+	length, err := c.readPayloadLength(header[1] & 0x7f)
+	if err != nil {
+		return 0, nil, err
+	}
+	if length > maxMessageSize {
+		return 0, nil, fmt.Errorf("websocket message size %d exceeds limit", length)
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return 0, nil, fmt.Errorf("reading frame mask: %w", err)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return 0, nil, fmt.Errorf("reading frame payload: %w", err)
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
+
+// readPayloadLength reads the extended payload length if the frame uses it.
+func (c *websocketConn) readPayloadLength(length byte) (uint64, error) {
+	switch length {
+	case 126:
+		var b [2]byte
+		if _, err := io.ReadFull(c.reader, b[:]); err != nil {
+			return 0, fmt.Errorf("reading frame length: %w", err)
+		}
+		return uint64(binary.BigEndian.Uint16(b[:])), nil
+
+	case 127:
+		var b [8]byte
+		if _, err := io.ReadFull(c.reader, b[:]); err != nil {
+			return 0, fmt.Errorf("reading frame length: %w", err)
+		}
+		return binary.BigEndian.Uint64(b[:]), nil
+
+	default:
+		return uint64(length), nil
+	}
+}
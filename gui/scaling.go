@@ -0,0 +1,100 @@
+package gui
+
+import (
+	"image"
+	"math"
+	"sync/atomic"
+)
+
+// Filter defines the filter that is used when scaling the image to the window size.
+type Filter int
+
+// scaling filters.
+const (
+	// NearestFilter uses nearest pixel sampling and keeps the image sharp.
+	NearestFilter Filter = iota
+	// LinearFilter uses bilinear filtering and smooths the image.
+	LinearFilter
+)
+
+// AspectRatio defines the aspect ratio that the rendered image is displayed with.
+type AspectRatio int
+
+// aspect ratios.
+const (
+	// SquarePixels displays every pixel of the image as a square.
+	SquarePixels AspectRatio = iota
+	// NESPixels displays every pixel with the 8:7 pixel aspect ratio of the NES.
+	NESPixels
+	// CRT4x3 displays the whole image with the 4:3 aspect ratio of a CRT TV.
+	CRT4x3
+)
+
+// Scaling contains the options for scaling the rendered image to the window.
+type Scaling struct {
+	Filter      Filter
+	AspectRatio AspectRatio
+
+	// Integer restricts the scaling of the image to integer factors.
+	Integer bool
+	// Stretch fills the whole window ignoring the aspect ratio, otherwise
+	// the image gets centered in the window with black letterbox borders.
+	Stretch bool
+}
+
+var scaling atomic.Pointer[Scaling]
+
+// SetScaling sets the scaling options that the renderer uses for the
+// next rendered frame. It is safe to call while the renderer is running.
+func SetScaling(s Scaling) {
+	scaling.Store(&s)
+}
+
+// CurrentScaling returns the scaling options that are currently used by the renderer.
+func CurrentScaling() Scaling {
+	s := scaling.Load()
+	if s == nil {
+		return Scaling{}
+	}
+	return *s
+}
+
+// displayAspect returns the display aspect ratio of an image with the given size.
+func (a AspectRatio) displayAspect(imageWidth, imageHeight int) float64 {
+	switch a {
+	case NESPixels:
+		return float64(imageWidth) * 8 / 7 / float64(imageHeight)
+	case CRT4x3:
+		return 4.0 / 3.0
+	default:
+		return float64(imageWidth) / float64(imageHeight)
+	}
+}
+
+// WindowSize returns the initial window size for the given image size and scale factor.
+func (s Scaling) WindowSize(imageWidth, imageHeight int, scaleFactor float64) (int, int) {
+	height := float64(imageHeight) * scaleFactor
+	width := height * s.AspectRatio.displayAspect(imageWidth, imageHeight)
+	return int(math.Round(width)), int(math.Round(height))
+}
+
+// Viewport returns the rectangle of the window that the image gets rendered to.
+func (s Scaling) Viewport(imageWidth, imageHeight, windowWidth, windowHeight int) image.Rectangle {
+	if s.Stretch || imageWidth == 0 || imageHeight == 0 {
+		return image.Rect(0, 0, windowWidth, windowHeight)
+	}
+
+	displayWidth := float64(imageHeight) * s.AspectRatio.displayAspect(imageWidth, imageHeight)
+	displayHeight := float64(imageHeight)
+
+	factor := math.Min(float64(windowWidth)/displayWidth, float64(windowHeight)/displayHeight)
+	if s.Integer && factor >= 1 {
+		factor = math.Floor(factor)
+	}
+
+	width := int(math.Round(displayWidth * factor))
+	height := int(math.Round(displayHeight * factor))
+	x := (windowWidth - width) / 2
+	y := (windowHeight - height) / 2
+	return image.Rect(x, y, x+width, y+height)
+}
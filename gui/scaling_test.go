@@ -0,0 +1,82 @@
+package gui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestScalingViewport(t *testing.T) {
+	tests := []struct {
+		name     string
+		scaling  Scaling
+		window   image.Point
+		expected image.Rectangle
+	}{
+		{
+			name:     "exact fit",
+			window:   image.Pt(512, 480),
+			expected: image.Rect(0, 0, 512, 480),
+		},
+		{
+			name:     "letterbox horizontal",
+			window:   image.Pt(800, 480),
+			expected: image.Rect(144, 0, 656, 480),
+		},
+		{
+			name:     "letterbox vertical",
+			window:   image.Pt(512, 600),
+			expected: image.Rect(0, 60, 512, 540),
+		},
+		{
+			name:     "stretch",
+			scaling:  Scaling{Stretch: true},
+			window:   image.Pt(800, 600),
+			expected: image.Rect(0, 0, 800, 600),
+		},
+		{
+			name:     "integer scaling",
+			scaling:  Scaling{Integer: true},
+			window:   image.Pt(700, 700),
+			expected: image.Rect(94, 110, 606, 590),
+		},
+		{
+			name:     "4:3 aspect ratio",
+			scaling:  Scaling{AspectRatio: CRT4x3},
+			window:   image.Pt(640, 480),
+			expected: image.Rect(0, 0, 640, 480),
+		},
+		{
+			name:     "NES pixel aspect ratio",
+			scaling:  Scaling{AspectRatio: NESPixels},
+			window:   image.Pt(585, 480),
+			expected: image.Rect(0, 0, 585, 480),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viewport := test.scaling.Viewport(256, 240, test.window.X, test.window.Y)
+			assert.Equal(t, test.expected, viewport)
+		})
+	}
+}
+
+func TestScalingWindowSize(t *testing.T) {
+	width, height := Scaling{}.WindowSize(256, 240, 2.0)
+	assert.Equal(t, 512, width)
+	assert.Equal(t, 480, height)
+
+	width, height = Scaling{AspectRatio: CRT4x3}.WindowSize(256, 240, 2.0)
+	assert.Equal(t, 640, width)
+	assert.Equal(t, 480, height)
+}
+
+func TestSetScaling(t *testing.T) {
+	prev := CurrentScaling()
+	defer SetScaling(prev)
+
+	SetScaling(Scaling{Filter: LinearFilter})
+	assert.Equal(t, LinearFilter, CurrentScaling().Filter)
+}
@@ -37,6 +37,11 @@ const (
 	SDL_PIXELFORMAT_ABGR8888 = 0x16762004
 
 	SDL_TEXTUREACCESS_STREAMING = 1
+
+	SDL_SCALEMODE_NEAREST = 0
+	SDL_SCALEMODE_LINEAR  = 1
+
+	SDL_HINT_RENDER_SCALE_QUALITY = "SDL_RENDER_SCALE_QUALITY"
 )
 
 // events
@@ -50,9 +55,25 @@ const (
 	SDL_MOUSEBUTTONDOWN = 0x401
 	SDL_MOUSEBUTTONUP   = 0x402
 	SDL_MOUSEWHEEL      = 0x403
+	SDL_DROPFILE        = 0x1000
 	SDL_LASTEVENT       = 0x1FFF
 )
 
+// window events
+const (
+	SDL_WINDOWEVENT_SIZE_CHANGED = 6
+	SDL_WINDOWEVENT_FOCUS_GAINED = 12
+	SDL_WINDOWEVENT_FOCUS_LOST   = 13
+	SDL_WINDOWEVENT_CLOSE        = 14
+)
+
+type rect struct {
+	X int32
+	Y int32
+	W int32
+	H int32
+}
+
 type event struct {
 	Type uint32
 	_    [64]byte
@@ -69,6 +90,25 @@ type keyboardEvent struct {
 	Keysym    keySym // Keysym representing the key that was pressed or released
 }
 
+type windowEvent struct {
+	Type      uint32 // WINDOWEVENT
+	Timestamp uint32 // timestamp of the event
+	WindowID  uint32 // the associated window
+	Event     uint8  // window event ID
+	_         uint8  // padding
+	_         uint8  // padding
+	_         uint8  // padding
+	Data1     int32  // event dependent data
+	Data2     int32  // event dependent data
+}
+
+type dropEvent struct {
+	Type      uint32 // DROPFILE
+	Timestamp uint32 // timestamp of the event
+	File      *byte  // file name, needs to be freed using Free
+	WindowID  uint32 // the window that was dropped on, if any
+}
+
 type scancode uint32
 type keycode int32
 
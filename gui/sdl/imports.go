@@ -3,6 +3,7 @@ package sdl
 import (
 	"fmt"
 	"runtime"
+	"sort"
 
 	"github.com/ebitengine/purego"
 )
@@ -14,16 +15,30 @@ var (
 	GetError func() string
 	// Quit quits the SDL library.
 	Quit func()
+	// Free frees memory allocated by SDL.
+	Free func(mem *byte)
+	// GetTicks returns the number of milliseconds since the SDL library initialization.
+	GetTicks func() uint32
 
 	// CreateWindow creates a window.
 	CreateWindow func(title string, x, y, w, h int32, flags uint32) uintptr
 	// DestroyWindow destroys a window.
 	DestroyWindow func(window uintptr)
+	// GetWindowID returns the numeric ID of a window.
+	GetWindowID func(window uintptr) uint32
+	// HideWindow hides a window.
+	HideWindow func(window uintptr)
 
 	// CreateRenderer creates a renderer.
 	CreateRenderer func(window uintptr, index int, flags uint32) uintptr
 	// RenderCopy copies a portion of the texture to the rendering target.
-	RenderCopy func(renderer uintptr, texture uintptr, srcRect uintptr, dstRect uintptr) int
+	RenderCopy func(renderer uintptr, texture uintptr, srcRect *rect, dstRect *rect) int
+	// RenderClear clears the current rendering target with the drawing color.
+	RenderClear func(renderer uintptr) int
+	// SetRenderDrawColor sets the color used for drawing operations.
+	SetRenderDrawColor func(renderer uintptr, r, g, b, a uint8) int
+	// GetRendererOutputSize returns the output size in pixels of a rendering context.
+	GetRendererOutputSize func(renderer uintptr, w, h *int32) int
 	// RenderPresent updates the screen with any rendering performed.
 	RenderPresent func(renderer uintptr)
 	// DestroyRenderer destroys a renderer.
@@ -35,25 +50,45 @@ var (
 	UpdateTexture func(texture uintptr, rect uintptr, pixels []byte, pitch int) int
 	// DestroyTexture destroys a texture.
 	DestroyTexture func(texture uintptr)
+	// SetTextureScaleMode sets the scale mode used for texture scale operations.
+	// It is nil if the SDL library is older than version 2.0.12.
+	SetTextureScaleMode func(texture uintptr, scaleMode int) int
+
+	// SetHint sets a configuration hint.
+	SetHint func(name, value string) int
 
 	// PollEvent polls for currently pending events.
 	PollEvent func(event *event) int
 )
 
 var imports = map[string]any{
-	"SDL_CreateRenderer":  &CreateRenderer,
-	"SDL_CreateTexture":   &CreateTexture,
-	"SDL_CreateWindow":    &CreateWindow,
-	"SDL_DestroyRenderer": &DestroyRenderer,
-	"SDL_DestroyTexture":  &DestroyTexture,
-	"SDL_DestroyWindow":   &DestroyWindow,
-	"SDL_GetError":        &GetError,
-	"SDL_Init":            &Init,
-	"SDL_PollEvent":       &PollEvent,
-	"SDL_Quit":            &Quit,
-	"SDL_RenderCopy":      &RenderCopy,
-	"SDL_RenderPresent":   &RenderPresent,
-	"SDL_UpdateTexture":   &UpdateTexture,
+	"SDL_CreateRenderer":        &CreateRenderer,
+	"SDL_CreateTexture":         &CreateTexture,
+	"SDL_CreateWindow":          &CreateWindow,
+	"SDL_DestroyRenderer":       &DestroyRenderer,
+	"SDL_DestroyTexture":        &DestroyTexture,
+	"SDL_DestroyWindow":         &DestroyWindow,
+	"SDL_Free":                  &Free,
+	"SDL_GetError":              &GetError,
+	"SDL_GetRendererOutputSize": &GetRendererOutputSize,
+	"SDL_GetTicks":              &GetTicks,
+	"SDL_GetWindowID":           &GetWindowID,
+	"SDL_HideWindow":            &HideWindow,
+	"SDL_Init":                  &Init,
+	"SDL_PollEvent":             &PollEvent,
+	"SDL_Quit":                  &Quit,
+	"SDL_RenderClear":           &RenderClear,
+	"SDL_RenderCopy":            &RenderCopy,
+	"SDL_RenderPresent":         &RenderPresent,
+	"SDL_SetHint":               &SetHint,
+	"SDL_SetRenderDrawColor":    &SetRenderDrawColor,
+	"SDL_UpdateTexture":         &UpdateTexture,
+}
+
+// optionalImports contains functions that are not supported by all SDL 2
+// versions, the function variables stay nil if the library does not export them.
+var optionalImports = map[string]any{
+	"SDL_SetTextureScaleMode": &SetTextureScaleMode, // SDL 2.0.12+
 }
 
 func registerFunction(lib uintptr, name string, ptr any) (err error) {
@@ -67,6 +102,26 @@ func registerFunction(lib uintptr, name string, ptr any) (err error) {
 	return nil
 }
 
+// registerFunctions registers all mandatory and available optional functions.
+func registerFunctions(lib uintptr) error {
+	names := make([]string, 0, len(imports))
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := registerFunction(lib, name, imports[name]); err != nil {
+			return err
+		}
+	}
+
+	for name, ptr := range optionalImports {
+		_ = registerFunction(lib, name, ptr)
+	}
+	return nil
+}
+
 func getSDLSystemLibrary() (string, error) {
 	switch runtime.GOOS {
 	case "darwin":
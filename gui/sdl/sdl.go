@@ -3,7 +3,9 @@ package sdl
 
 import (
 	"fmt"
+	"image"
 	"runtime"
+	"time"
 	"unsafe"
 
 	"github.com/retroenv/retrogolib/gui"
@@ -12,100 +14,332 @@ import (
 const bytesPerPixel = 4
 
 // Setup initializes the SDL library and returns a render and cleanup function.
+// Secondary windows of the backend are created and rendered together with
+// the main window.
 func Setup(backend gui.Backend) (guiRender func() (bool, error), guiCleanup func(), err error) {
 	runtime.LockOSThread()
 
 	dimensions := backend.Dimensions()
 
-	window, renderer, tex, err := setupSDL(dimensions, backend)
+	gui.SetScaling(dimensions.Scaling)
+
+	if err := setupLibrary(); err != nil {
+		return nil, nil, fmt.Errorf("setting up SDL library: %w", err)
+	}
+	if ret := Init(SDL_INIT_EVERYTHING); ret != 0 {
+		return nil, nil, fmt.Errorf("initializing SDL: %s", GetError())
+	}
+
+	state := &renderState{}
+	cleanup := func() {
+		for _, w := range state.windows {
+			w.destroy()
+		}
+		if state.main != nil {
+			state.main.destroy()
+		}
+		Quit()
+	}
+
+	state.main, err = createWindow(backend, dimensions.Scaling)
 	if err != nil {
+		cleanup()
 		return nil, nil, err
 	}
 
-	render := func() (bool, error) {
-		return renderSDL(dimensions, backend, renderer, tex)
+	for _, secondary := range gui.SecondaryWindows(backend) {
+		w, err := createWindow(secondary, gui.Scaling{})
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		state.windows = append(state.windows, w)
 	}
 
-	cleanup := func() {
-		DestroyTexture(tex)
-		DestroyRenderer(renderer)
-		DestroyWindow(window)
-		Quit()
+	render := func() (bool, error) {
+		return renderSDL(backend, state)
 	}
 	return render, cleanup, nil
 }
 
-// setupSDL initializes the SDL library and creates the window, renderer, and texture.
-func setupSDL(dimensions gui.Dimensions, backend gui.Backend) (uintptr, uintptr, uintptr, error) {
-	if err := setupLibrary(); err != nil {
-		return 0, 0, 0, fmt.Errorf("setting up SDL library: %w", err)
+// renderState contains the state of the renderer that is kept between rendered frames.
+type renderState struct {
+	main    *window
+	windows []*window // secondary windows
+}
+
+// window contains the SDL resources of a window.
+type window struct {
+	source     gui.Window
+	dimensions gui.Dimensions
+	id         uint32
+
+	window   uintptr
+	renderer uintptr
+	tex      uintptr
+
+	filter gui.Filter // filter that is currently set for the texture
+	hidden bool
+}
+
+// createWindow creates the window, renderer, and texture for the given window source.
+func createWindow(source gui.Window, scaling gui.Scaling) (*window, error) {
+	dimensions := source.Dimensions()
+	w := &window{
+		source:     source,
+		dimensions: dimensions,
+		filter:     -1, // force setting the filter of the texture on first render
 	}
 
-	if ret := Init(SDL_INIT_EVERYTHING); ret != 0 {
-		return 0, 0, 0, fmt.Errorf("initializing SDL: %s", GetError())
+	width, height := scaling.WindowSize(dimensions.Width, dimensions.Height, dimensions.ScaleFactor)
+
+	w.window = CreateWindow(source.WindowTitle(), SDL_WINDOWPOS_CENTERED,
+		SDL_WINDOWPOS_CENTERED, int32(width), int32(height),
+		SDL_WINDOW_SHOWN|SDL_WINDOW_RESIZABLE|SDL_WINDOW_ALLOW_HIGHDPI)
+	if w.window == 0 {
+		return nil, fmt.Errorf("creating SDL window: %s", GetError())
 	}
+	w.id = GetWindowID(w.window)
 
-	height := int32(float64(dimensions.Height) * dimensions.ScaleFactor)
-	width := int32(float64(dimensions.Width) * dimensions.ScaleFactor)
+	w.renderer = CreateRenderer(w.window, -1, SDL_RENDERER_ACCELERATED)
+	if w.renderer == 0 {
+		w.destroy()
+		return nil, fmt.Errorf("creating SDL renderer: %s", GetError())
+	}
 
-	window := CreateWindow(backend.WindowTitle(), SDL_WINDOWPOS_CENTERED,
-		SDL_WINDOWPOS_CENTERED, width, height,
-		SDL_WINDOW_SHOWN|SDL_WINDOW_ALLOW_HIGHDPI)
-	if window == 0 {
-		return 0, 0, 0, fmt.Errorf("creating SDL window: %s", GetError())
+	if err := w.createTexture(); err != nil {
+		w.destroy()
+		return nil, err
 	}
 
-	renderer := CreateRenderer(window, -1, SDL_RENDERER_ACCELERATED)
-	if renderer == 0 {
-		return 0, 0, 0, fmt.Errorf("creating SDL renderer: %s", GetError())
+	return w, nil
+}
+
+// createTexture creates the streaming texture that the frames are copied to.
+func (w *window) createTexture() error {
+	w.tex = CreateTexture(w.renderer, uint32(SDL_PIXELFORMAT_ABGR8888),
+		SDL_TEXTUREACCESS_STREAMING, int32(w.dimensions.Width), int32(w.dimensions.Height))
+	if w.tex == 0 {
+		return fmt.Errorf("creating SDL texture: %s", GetError())
 	}
+	return nil
+}
 
-	tex := CreateTexture(renderer, uint32(SDL_PIXELFORMAT_ABGR8888),
-		SDL_TEXTUREACCESS_STREAMING, int32(dimensions.Width), int32(dimensions.Height))
-	if tex == 0 {
-		return 0, 0, 0, fmt.Errorf("creating SDL texture: %s", GetError())
+// destroy frees the SDL resources of the window.
+func (w *window) destroy() {
+	if w.tex != 0 {
+		DestroyTexture(w.tex)
+	}
+	if w.renderer != 0 {
+		DestroyRenderer(w.renderer)
 	}
+	DestroyWindow(w.window)
+}
 
-	return window, renderer, tex, nil
+// hide hides a secondary window after the user closed it.
+func (w *window) hide() {
+	HideWindow(w.window)
+	w.hidden = true
 }
 
-// renderSDL renders the image to the SDL window.
-func renderSDL(dimensions gui.Dimensions, backend gui.Backend, renderer uintptr, tex uintptr) (bool, error) {
+// renderSDL processes the pending events and renders all windows.
+func renderSDL(backend gui.Backend, state *renderState) (bool, error) {
 	var ev event
 	for ret := PollEvent(&ev); ret != 0; ret = PollEvent(&ev) {
-		switch ev.Type {
-		case SDL_QUIT:
+		if !handleEvent(backend, state, &ev) {
 			return false, nil
+		}
+	}
 
-		case SDL_KEYDOWN:
-			keyEvent := (*keyboardEvent)(unsafe.Pointer(&ev))
-			if keyEvent.Keysym.Sym == K_ESCAPE {
-				return false, nil
-			}
+	img, err := gui.PrepareFrame(backend)
+	if err != nil {
+		return false, fmt.Errorf("preparing frame: %w", err)
+	}
+	if err := state.main.render(img, gui.CurrentScaling()); err != nil {
+		return false, err
+	}
+	gui.InputLatency().FramePresented(time.Now())
+
+	for _, w := range state.windows {
+		if w.hidden {
+			continue
+		}
+		if err := w.render(w.source.Image(), gui.Scaling{}); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
 
-			controllerKey, ok := keyMapping[keyEvent.Keysym.Sym]
-			if ok {
-				backend.KeyDown(controllerKey)
+// render updates the texture of the window with the image and presents it.
+func (w *window) render(img *image.RGBA, scaling gui.Scaling) error {
+	if scaling.Filter != w.filter {
+		if err := w.setFilter(scaling.Filter); err != nil {
+			return err
+		}
+	}
+
+	if ret := UpdateTexture(w.tex, 0, img.Pix, w.dimensions.Width*bytesPerPixel); ret != 0 {
+		return fmt.Errorf("updating SDL texture: %s", GetError())
+	}
+
+	if err := w.renderScaled(scaling); err != nil {
+		return err
+	}
+	RenderPresent(w.renderer)
+	return nil
+}
+
+// windowByID returns the secondary window with the given SDL window ID.
+func (s *renderState) windowByID(id uint32) *window {
+	for _, w := range s.windows {
+		if w.id == id {
+			return w
+		}
+	}
+	return nil
+}
+
+// handleEvent processes an SDL event and returns whether the renderer should keep running.
+func handleEvent(backend gui.Backend, state *renderState, ev *event) bool {
+	switch ev.Type {
+	case SDL_QUIT:
+		return !gui.RequestClose(backend)
+
+	case SDL_KEYDOWN:
+		keyEvent := (*keyboardEvent)(unsafe.Pointer(ev))
+		if keyEvent.Keysym.Sym == K_ESCAPE {
+			return !gui.RequestClose(backend)
+		}
+
+		controllerKey, ok := keyMapping[keyEvent.Keysym.Sym]
+		if ok {
+			recordInputLatency(keyEvent.Timestamp)
+			backend.KeyDown(controllerKey)
+		}
+
+	case SDL_KEYUP:
+		keyEvent := (*keyboardEvent)(unsafe.Pointer(ev))
+		controllerKey, ok := keyMapping[keyEvent.Keysym.Sym]
+		if ok {
+			recordInputLatency(keyEvent.Timestamp)
+			backend.KeyUp(controllerKey)
+		}
+
+	case SDL_WINDOWEVENT:
+		windowEvent := (*windowEvent)(unsafe.Pointer(ev))
+		if secondary := state.windowByID(windowEvent.WindowID); secondary != nil {
+			if windowEvent.Event == SDL_WINDOWEVENT_CLOSE {
+				secondary.hide()
 			}
+			return true
+		}
 
-		case SDL_KEYUP:
-			keyEvent := (*keyboardEvent)(unsafe.Pointer(&ev))
-			controllerKey, ok := keyMapping[keyEvent.Keysym.Sym]
-			if ok {
-				backend.KeyUp(controllerKey)
+		switch windowEvent.Event {
+		case SDL_WINDOWEVENT_CLOSE:
+			// SDL only sends a quit event when the last window gets closed
+			if len(state.windows) > 0 {
+				return !gui.RequestClose(backend)
 			}
+		case SDL_WINDOWEVENT_SIZE_CHANGED:
+			gui.NotifyWindowResized(backend, int(windowEvent.Data1), int(windowEvent.Data2))
+		case SDL_WINDOWEVENT_FOCUS_GAINED:
+			gui.NotifyFocusChanged(backend, true)
+		case SDL_WINDOWEVENT_FOCUS_LOST:
+			gui.NotifyFocusChanged(backend, false)
+		}
+
+	case SDL_DROPFILE:
+		dropEvent := (*dropEvent)(unsafe.Pointer(ev))
+		if dropEvent.File != nil {
+			path := goString(dropEvent.File)
+			Free(dropEvent.File)
+			gui.NotifyFileDropped(backend, path)
 		}
 	}
+	return true
+}
 
-	image := backend.Image()
-	if ret := UpdateTexture(tex, 0, image.Pix, dimensions.Width*bytesPerPixel); ret != 0 {
-		return false, fmt.Errorf("updating SDL texture: %s", GetError())
+// recordInputLatency passes the time that SDL received an input event to the
+// latency monitor. The event timestamp is in milliseconds since the SDL
+// library initialization and gets converted to wall clock time.
+func recordInputLatency(timestamp uint32) {
+	monitor := gui.InputLatency()
+	if !monitor.Enabled() {
+		return
 	}
 
-	if ret := RenderCopy(renderer, tex, 0, 0); ret != 0 {
-		return false, fmt.Errorf("copying SDL texture: %s", GetError())
+	age := time.Duration(GetTicks()-timestamp) * time.Millisecond
+	monitor.InputReceived(time.Now().Add(-age))
+}
+
+// goString converts a null terminated C string to a Go string.
+func goString(p *byte) string {
+	n := 0
+	for *(*byte)(unsafe.Add(unsafe.Pointer(p), n)) != 0 {
+		n++
 	}
-	RenderPresent(renderer)
+	return string(unsafe.Slice(p, n))
+}
 
-	return true, nil
+// setFilter sets the scaling filter of the texture. SDL versions before
+// 2.0.12 do not support changing the scale mode of a texture, the scale
+// quality hint is set instead and the texture is recreated to apply it.
+func (w *window) setFilter(filter gui.Filter) error {
+	linear := filter == gui.LinearFilter
+
+	if SetTextureScaleMode != nil {
+		mode := SDL_SCALEMODE_NEAREST
+		if linear {
+			mode = SDL_SCALEMODE_LINEAR
+		}
+		if ret := SetTextureScaleMode(w.tex, mode); ret != 0 {
+			return fmt.Errorf("setting SDL texture scale mode: %s", GetError())
+		}
+		w.filter = filter
+		return nil
+	}
+
+	quality := "nearest"
+	if linear {
+		quality = "linear"
+	}
+	if SetHint(SDL_HINT_RENDER_SCALE_QUALITY, quality) == 0 {
+		return fmt.Errorf("setting SDL render scale quality hint: %s", GetError())
+	}
+	DestroyTexture(w.tex)
+	w.tex = 0
+	if err := w.createTexture(); err != nil {
+		return err
+	}
+	w.filter = filter
+	return nil
+}
+
+// renderScaled copies the texture to the window using the given scaling options.
+func (w *window) renderScaled(scaling gui.Scaling) error {
+	var windowWidth, windowHeight int32
+	if ret := GetRendererOutputSize(w.renderer, &windowWidth, &windowHeight); ret != 0 {
+		return fmt.Errorf("getting SDL renderer output size: %s", GetError())
+	}
+
+	viewport := scaling.Viewport(w.dimensions.Width, w.dimensions.Height, int(windowWidth), int(windowHeight))
+	dst := rect{
+		X: int32(viewport.Min.X),
+		Y: int32(viewport.Min.Y),
+		W: int32(viewport.Dx()),
+		H: int32(viewport.Dy()),
+	}
+
+	// clear the window to draw black letterbox borders
+	SetRenderDrawColor(w.renderer, 0, 0, 0, 0xff)
+	if ret := RenderClear(w.renderer); ret != 0 {
+		return fmt.Errorf("clearing SDL renderer: %s", GetError())
+	}
+
+	if ret := RenderCopy(w.renderer, w.tex, nil, &dst); ret != 0 {
+		return fmt.Errorf("copying SDL texture: %s", GetError())
+	}
+	return nil
 }
@@ -4,7 +4,6 @@ package sdl
 
 import (
 	"fmt"
-	"sort"
 
 	"github.com/ebitengine/purego"
 )
@@ -20,17 +19,5 @@ func setupLibrary() error {
 		return fmt.Errorf("loading SDL library: %w", err)
 	}
 
-	names := make([]string, 0, len(imports))
-	for name := range imports {
-		names = append(names, name)
-	}
-	sort.Strings(names)
-
-	for _, name := range names {
-		ptr := imports[name]
-		if err := registerFunction(lib, name, ptr); err != nil {
-			return err
-		}
-	}
-	return nil
+	return registerFunctions(lib)
 }
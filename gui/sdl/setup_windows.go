@@ -4,7 +4,6 @@ package sdl
 
 import (
 	"fmt"
-	"sort"
 	"syscall"
 )
 
@@ -19,19 +18,7 @@ func setupLibrary() error {
 		return fmt.Errorf("loading SDL library: %w", err)
 	}
 
-	names := make([]string, 0, len(imports))
-	for name := range imports {
-		names = append(names, name)
-	}
-	sort.Strings(names)
-
-	for _, name := range names {
-		ptr := imports[name]
-		if err := registerFunction(lib, name, ptr); err != nil {
-			return err
-		}
-	}
-	return nil
+	return registerFunctions(lib)
 }
 
 func loadLibrary(libName string) (handle uintptr, err error) {
@@ -0,0 +1,129 @@
+package gui
+
+import (
+	"image/color"
+	"sync/atomic"
+)
+
+// Theme defines the colors and font scale of the debug rendering.
+type Theme struct {
+	Name string
+
+	Text       color.RGBA // overlay text color
+	Accent     color.RGBA // color of the register panel text
+	Background color.RGBA // text background, blended using its alpha value
+
+	// Palette contains distinct colors for debug views that need to
+	// differentiate categories, like code and data in memory viewers.
+	Palette []color.RGBA
+
+	FontScale int // integer scale of the overlay font, values below 1 are treated as 1
+}
+
+// Built-in themes.
+var (
+	// DefaultTheme is white text on a darkened background.
+	DefaultTheme = Theme{
+		Name:       "default",
+		Text:       color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		Accent:     color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		Background: color.RGBA{A: 0xc0},
+		Palette: []color.RGBA{
+			{R: 0xff, G: 0x00, B: 0x00, A: 0xff},
+			{R: 0x00, G: 0xff, B: 0x00, A: 0xff},
+			{R: 0x00, G: 0x00, B: 0xff, A: 0xff},
+			{R: 0xff, G: 0xff, B: 0x00, A: 0xff},
+			{R: 0xff, G: 0x00, B: 0xff, A: 0xff},
+			{R: 0x00, G: 0xff, B: 0xff, A: 0xff},
+		},
+		FontScale: 1,
+	}
+
+	// HighContrastTheme is yellow text on an opaque black background with
+	// a doubled font size.
+	HighContrastTheme = Theme{
+		Name:       "high-contrast",
+		Text:       color.RGBA{R: 0xff, G: 0xff, B: 0x00, A: 0xff},
+		Accent:     color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		Background: color.RGBA{A: 0xff},
+		Palette: []color.RGBA{
+			{R: 0xff, G: 0xff, B: 0x00, A: 0xff},
+			{R: 0x00, G: 0xff, B: 0xff, A: 0xff},
+			{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+			{R: 0xff, G: 0x00, B: 0xff, A: 0xff},
+		},
+		FontScale: 2,
+	}
+
+	// ColorBlindTheme uses the Okabe-Ito palette, which is distinguishable
+	// for the common forms of color vision deficiency.
+	ColorBlindTheme = Theme{
+		Name:       "color-blind",
+		Text:       color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		Accent:     color.RGBA{R: 0xe6, G: 0x9f, B: 0x00, A: 0xff},
+		Background: color.RGBA{A: 0xc0},
+		Palette: []color.RGBA{
+			{R: 0xe6, G: 0x9f, B: 0x00, A: 0xff}, // orange
+			{R: 0x56, G: 0xb4, B: 0xe9, A: 0xff}, // sky blue
+			{R: 0x00, G: 0x9e, B: 0x73, A: 0xff}, // bluish green
+			{R: 0xf0, G: 0xe4, B: 0x42, A: 0xff}, // yellow
+			{R: 0x00, G: 0x72, B: 0xb2, A: 0xff}, // blue
+			{R: 0xd5, G: 0x5e, B: 0x00, A: 0xff}, // vermillion
+			{R: 0xcc, G: 0x79, B: 0xa7, A: 0xff}, // reddish purple
+		},
+		FontScale: 1,
+	}
+)
+
+var themes = []Theme{DefaultTheme, HighContrastTheme, ColorBlindTheme}
+
+var theme atomic.Pointer[Theme]
+
+// Themes returns the built-in themes.
+func Themes() []Theme {
+	return append([]Theme(nil), themes...)
+}
+
+// ThemeByName returns the built-in theme with the given name, which allows
+// selecting a theme by a configuration value.
+func ThemeByName(name string) (Theme, bool) {
+	for _, t := range themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// SetTheme sets the theme that is used for the debug rendering.
+// It is safe to call while the renderer is running.
+func SetTheme(t Theme) {
+	theme.Store(&t)
+}
+
+// CurrentTheme returns the theme that is used for the debug rendering.
+func CurrentTheme() Theme {
+	t := theme.Load()
+	if t == nil {
+		return DefaultTheme
+	}
+	return *t
+}
+
+// PaletteColor returns the palette color for the index, the palette is
+// repeated for indexes that exceed its size.
+func (t Theme) PaletteColor(index int) color.RGBA {
+	if len(t.Palette) == 0 {
+		return t.Text
+	}
+	return t.Palette[index%len(t.Palette)]
+}
+
+// textStyle returns the style for drawing text in the given color.
+func (t Theme) textStyle(c color.RGBA) textStyle {
+	return textStyle{
+		color:      c,
+		background: t.Background,
+		scale:      max(t.FontScale, 1),
+	}
+}
@@ -0,0 +1,33 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestThemeByName(t *testing.T) {
+	theme, ok := ThemeByName("color-blind")
+	assert.True(t, ok)
+	assert.Equal(t, ColorBlindTheme.Palette[1], theme.PaletteColor(len(theme.Palette)+1))
+
+	_, ok = ThemeByName("unknown")
+	assert.False(t, ok)
+}
+
+func TestOverlayTheme(t *testing.T) {
+	SetTheme(HighContrastTheme)
+	defer SetTheme(DefaultTheme)
+
+	o := NewOverlay()
+	o.SetEnabled(true)
+	img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	composed := o.Compose(img)
+
+	// the first pixel of the F of the FPS counter is scaled to 2x2 pixels
+	margin := overlayMargin * HighContrastTheme.FontScale
+	assert.Equal(t, HighContrastTheme.Text, composed.RGBAAt(margin+1, margin+1))
+	assert.Equal(t, color.RGBA{A: 0xff}, composed.RGBAAt(margin-1, margin-1))
+}
@@ -0,0 +1,28 @@
+package gui
+
+import "image"
+
+// Window is a secondary window like a pattern table or memory viewer of a
+// debugger. Its image gets rendered by the same render loop as the main
+// window, the scaling options only apply to the main window.
+type Window interface {
+	Image() *image.RGBA
+	Dimensions() Dimensions
+	WindowTitle() string
+}
+
+// WindowProvider can optionally be implemented by the Backend to open
+// secondary windows. The windows are created by Setup, closing a secondary
+// window hides it while the main window keeps running.
+type WindowProvider interface {
+	SecondaryWindows() []Window
+}
+
+// SecondaryWindows returns the secondary windows of the backend if it
+// implements the WindowProvider interface.
+func SecondaryWindows(backend Backend) []Window {
+	if provider, ok := backend.(WindowProvider); ok {
+		return provider.SecondaryWindows()
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+type multiWindowBackend struct {
+	testBackend
+
+	windows []Window
+}
+
+func (b *multiWindowBackend) SecondaryWindows() []Window {
+	return b.windows
+}
+
+func TestSecondaryWindows(t *testing.T) {
+	assert.Len(t, SecondaryWindows(&testBackend{}), 0)
+
+	viewer := &testBackend{}
+	b := &multiWindowBackend{windows: []Window{viewer}}
+	assert.Equal(t, []Window{viewer}, SecondaryWindows(b))
+}
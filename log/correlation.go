@@ -0,0 +1,50 @@
+package log
+
+import (
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// CorrelationTimeFormat is the time format of correlated dumps, the sub
+// second precision keeps trace entries and log records distinguishable.
+const CorrelationTimeFormat = "15:04:05.000000"
+
+// TraceEntry is an entry of an instruction trace, like a formatted CPU trace
+// step, that can be correlated with log records.
+type TraceEntry struct {
+	Time time.Time
+	Text string
+}
+
+// DumpCorrelated writes the newest count buffered records interleaved by
+// time with the trace entries to the writer. Trace entries are written with
+// the trace level. A negative count includes all buffered records, records
+// and trace entries with the same time keep the log record first.
+func (h *RingHandler) DumpCorrelated(w io.Writer, count int, trace []TraceEntry) error {
+	records := h.Last(count)
+
+	entries := make([]slog.Record, len(trace))
+	for i, entry := range trace {
+		entries[i] = slog.NewRecord(entry.Time, TraceLevel, entry.Text, 0)
+	}
+	slices.SortStableFunc(entries, func(a, b slog.Record) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	merged := make([]slog.Record, 0, len(records)+len(entries))
+	for len(records) > 0 && len(entries) > 0 {
+		if entries[0].Time.Before(records[0].Time) {
+			merged = append(merged, entries[0])
+			entries = entries[1:]
+		} else {
+			merged = append(merged, records[0])
+			records = records[1:]
+		}
+	}
+	merged = append(merged, records...)
+	merged = append(merged, entries...)
+
+	return dumpRecords(w, merged, CorrelationTimeFormat)
+}
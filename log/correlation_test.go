@@ -0,0 +1,36 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestDumpCorrelated(t *testing.T) {
+	ring := NewRingHandler(4, nil)
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i, msg := range []string{"first", "second", "third"} {
+		r := slog.NewRecord(start.Add(time.Duration(i)*time.Millisecond), InfoLevel, msg, 0)
+		assert.NoError(t, ring.Handle(context.Background(), r))
+	}
+
+	trace := []TraceEntry{
+		{Time: start.Add(2500 * time.Microsecond), Text: "C002  LDA #$01"},
+		{Time: start.Add(1500 * time.Microsecond), Text: "C000  SEI"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, ring.DumpCorrelated(&buf, 2, trace))
+	expected := "03:04:05.001000  INFO    second\n" +
+		"03:04:05.001500  TRACE   C000  SEI\n" +
+		"03:04:05.002000  INFO    third\n" +
+		"03:04:05.002500  TRACE   C002  LDA #$01\n"
+	assert.Equal(t, expected, buf.String())
+
+	assert.Len(t, ring.Last(-1), 3)
+	assert.Len(t, ring.Last(0), 0)
+}
@@ -3,6 +3,7 @@ package log
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 )
 
@@ -114,3 +115,65 @@ func Float32(key string, val float32) Field {
 func Float64(key string, val float64) Field {
 	return slog.Float64(key, val)
 }
+
+// Hex8 constructs a Field with the given key and the value formatted as
+// hexadecimal byte like $FF. The value is a snapshot taken when the field
+// is constructed.
+func Hex8(key string, val uint8) Field {
+	return slog.Any(key, hex8(val))
+}
+
+// Hex16 constructs a Field with the given key and the value formatted as
+// hexadecimal word like $FFFF. The value is a snapshot taken when the field
+// is constructed.
+func Hex16(key string, val uint16) Field {
+	return slog.Any(key, hex16(val))
+}
+
+// HexDump constructs a Field with the given key and the data formatted as
+// space separated hexadecimal bytes. If the data is longer than maxLen
+// bytes, the output is truncated and the total length gets appended.
+// A maxLen of 0 or less disables the truncation. The data is copied when
+// the field is constructed, buffering or asynchronous handlers output the
+// data as it was at the time of logging.
+func HexDump(key string, data []byte, maxLen int) Field {
+	length := len(data)
+	if maxLen > 0 && length > maxLen {
+		data = data[:maxLen]
+	}
+	return slog.Any(key, hexDump{data: slices.Clone(data), length: length})
+}
+
+type hex8 uint8
+
+// LogValue formats the value when the record is handled.
+func (h hex8) LogValue() slog.Value {
+	return slog.StringValue(fmt.Sprintf("$%02X", uint8(h)))
+}
+
+type hex16 uint16
+
+// LogValue formats the value when the record is handled.
+func (h hex16) LogValue() slog.Value {
+	return slog.StringValue(fmt.Sprintf("$%04X", uint16(h)))
+}
+
+type hexDump struct {
+	data   []byte // copy of the data, truncated to the maximum length
+	length int    // length of the original data
+}
+
+// LogValue formats the data when the record is handled.
+func (h hexDump) LogValue() slog.Value {
+	buf := make([]byte, 0, len(h.data)*3+16)
+	for i, b := range h.data {
+		if i > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = fmt.Appendf(buf, "%02X", b)
+	}
+	if h.length > len(h.data) {
+		buf = fmt.Appendf(buf, " ... (%d bytes)", h.length)
+	}
+	return slog.StringValue(string(buf))
+}
@@ -0,0 +1,28 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestHexFields(t *testing.T) {
+	cfg := DefaultConfig()
+	var buf bytes.Buffer
+	cfg.Output = &buf
+	cfg.TimeFormat = "-"
+	logger := NewWithConfig(cfg)
+
+	logger.Info("write", Hex16("address", 0x2007), Hex8("value", 0x0f),
+		HexDump("data", []byte{0xa9, 0x01, 0x8d, 0x00, 0x20}, 3), HexDump("full", []byte{1, 2}, 0))
+	assert.Equal(t, "INFO    write {\"address\":\"$2007\",\"value\":\"$0F\","+
+		"\"data\":\"A9 01 8D ... (5 bytes)\",\"full\":\"01 02\"}\n", buf.String())
+}
+
+func TestHexDumpSnapshot(t *testing.T) {
+	data := []byte{1, 2, 3}
+	field := HexDump("data", data, 0)
+	data[0] = 0xff
+	assert.Equal(t, "01 02 03", field.Value.Resolve().String())
+}
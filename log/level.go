@@ -2,6 +2,7 @@ package log
 
 import (
 	"log/slog"
+	"math"
 	"sync/atomic"
 )
 
@@ -33,6 +34,10 @@ const (
 // Level is a logging priority. Higher levels are more important.
 type Level = slog.Level
 
+// handlerLevel is the level that the handlers created by this package use,
+// the filtering by level is done by the logger.
+const handlerLevel = Level(math.MinInt)
+
 var (
 	defaultLevel   = uintptr(InfoLevel)
 	fatalLevelText = slog.StringValue("FATAL")
@@ -17,13 +17,19 @@ type Logger struct {
 	handler    slog.Handler
 	callerInfo bool
 	level      *slog.LevelVar
+	name       string
+	sampler    sampler
 }
 
-// New returns a new Logger instance.
-func New() *Logger {
+// New returns a new Logger instance that uses the default level and the
+// passed options.
+func New(options ...Option) *Logger {
 	cfg := Config{
 		Level: DefaultLevel(),
 	}
+	for _, option := range options {
+		option(&cfg)
+	}
 
 	return NewWithConfig(cfg)
 }
@@ -37,7 +43,7 @@ func NewWithConfig(cfg Config) *Logger {
 
 	opts := &slog.HandlerOptions{
 		AddSource: cfg.CallerInfo,
-		Level:     level,
+		Level:     handlerLevel, // the logger filters by its level and module level overrides
 	}
 
 	var output io.Writer
@@ -71,22 +77,39 @@ func NewWithConfig(cfg Config) *Logger {
 }
 
 // Named adds a new path segment to the logger's name. Segments are joined by
-// periods. By default, Loggers are unnamed.
+// periods. By default, Loggers are unnamed. The minimum level of named loggers
+// can be overridden by module using the SetLevel function of this package.
 func (l *Logger) Named(name string) *Logger {
 	newLogger := l.logger.WithGroup(name)
+	if l.name != "" {
+		name = l.name + "." + name
+	}
 	return &Logger{
-		logger: newLogger,
-		level:  l.level,
+		logger:     newLogger,
+		handler:    newLogger.Handler(),
+		callerInfo: l.callerInfo,
+		level:      l.level,
+		name:       name,
+		sampler:    l.sampler,
 	}
 }
 
+// Name returns the name of the logger, it is empty for unnamed loggers.
+func (l *Logger) Name() string {
+	return l.name
+}
+
 // With creates a child logger and adds structured context to it. Fields added
 // to the child don't affect the parent, and vice versa.
 func (l *Logger) With(fields ...any) *Logger {
 	newLogger := l.logger.With(fields...)
 	return &Logger{
-		logger: newLogger,
-		level:  l.level,
+		logger:     newLogger,
+		handler:    newLogger.Handler(),
+		callerInfo: l.callerInfo,
+		level:      l.level,
+		name:       l.name,
+		sampler:    l.sampler,
 	}
 }
 
@@ -96,6 +119,19 @@ func (l *Logger) Enabled(ctx context.Context, level Level) bool {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	return l.enabled(ctx, level)
+}
+
+// enabled reports whether the level is enabled by the minimum level of the
+// logger, which can be overridden by a module level, and by the handler.
+func (l *Logger) enabled(ctx context.Context, level Level) bool {
+	minLevel, ok := moduleLevelOverride(l.name)
+	if !ok {
+		minLevel = l.level.Level()
+	}
+	if level < minLevel {
+		return false
+	}
 	return l.handler.Enabled(ctx, level)
 }
 
@@ -178,9 +214,13 @@ func (l *Logger) Log(ctx context.Context, level Level, msg string, args ...any)
 		ctx = context.Background()
 	}
 
-	if !l.handler.Enabled(ctx, level) {
+	if !l.enabled(ctx, level) {
+		return
+	}
+	if l.sampler != nil && !l.sampler.allow(level, msg) {
 		return
 	}
+	countRecord(l.name, level)
 
 	r := slog.Record{
 		Time:    time.Now(),
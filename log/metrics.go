@@ -0,0 +1,145 @@
+package log
+
+import (
+	"cmp"
+	"expvar"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// MetricsName is the metric name used for the record counters in the
+// Prometheus text format output.
+const MetricsName = "retrogolib_log_records_total"
+
+// Counter is the number of records that were emitted by loggers of a
+// module at a level.
+type Counter struct {
+	Module string // name of the logger, empty for unnamed loggers
+	Level  Level
+	Count  uint64
+}
+
+type counterKey struct {
+	module string
+	level  Level
+}
+
+var (
+	countersMu sync.RWMutex
+	counters   = map[counterKey]*atomic.Uint64{}
+)
+
+// countRecord increments the counter of emitted records for the module and level.
+func countRecord(module string, level Level) {
+	key := counterKey{module: module, level: level}
+
+	countersMu.RLock()
+	counter, ok := counters[key]
+	countersMu.RUnlock()
+
+	if !ok {
+		countersMu.Lock()
+		counter, ok = counters[key]
+		if !ok {
+			counter = &atomic.Uint64{}
+			counters[key] = counter
+		}
+		countersMu.Unlock()
+	}
+
+	counter.Add(1)
+}
+
+// Metrics returns a snapshot of the counters of all emitted records,
+// sorted by module and level.
+func Metrics() []Counter {
+	countersMu.RLock()
+	result := make([]Counter, 0, len(counters))
+	for key, counter := range counters {
+		result = append(result, Counter{
+			Module: key.module,
+			Level:  key.level,
+			Count:  counter.Load(),
+		})
+	}
+	countersMu.RUnlock()
+
+	slices.SortFunc(result, func(a, b Counter) int {
+		if c := cmp.Compare(a.Module, b.Module); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Level, b.Level)
+	})
+	return result
+}
+
+// ResetMetrics resets all record counters.
+func ResetMetrics() {
+	countersMu.Lock()
+	clear(counters)
+	countersMu.Unlock()
+}
+
+// WriteMetrics writes the record counters in the Prometheus text exposition
+// format to the writer, which allows serving them on a metrics endpoint.
+func WriteMetrics(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "# HELP %s Number of emitted log records.\n# TYPE %s counter\n",
+		MetricsName, MetricsName); err != nil {
+		return fmt.Errorf("writing metrics header: %w", err)
+	}
+
+	for _, counter := range Metrics() {
+		if _, err := fmt.Fprintf(w, "%s{module=\"%s\",level=\"%s\"} %d\n", MetricsName,
+			escapeLabelValue(counter.Module), escapeLabelValue(levelName(counter.Level)), counter.Count); err != nil {
+			return fmt.Errorf("writing metric: %w", err)
+		}
+	}
+	return nil
+}
+
+// labelValueEscaper escapes label values as specified by the Prometheus text
+// exposition format, which only escapes backslash, double quote and newline.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+// PublishMetrics publishes the record counters as expvar variable with the
+// given name. The value is a map of module names to a map of level names
+// to counts. Like expvar.Publish, it panics if the name is already in use.
+func PublishMetrics(name string) {
+	expvar.Publish(name, expvar.Func(metricsByModule))
+}
+
+// metricsByModule returns the record counters as map of module names to a
+// map of level names to counts.
+func metricsByModule() any {
+	modules := map[string]map[string]uint64{}
+	for _, counter := range Metrics() {
+		levels, ok := modules[counter.Module]
+		if !ok {
+			levels = map[string]uint64{}
+			modules[counter.Module] = levels
+		}
+		levels[levelName(counter.Level)] = counter.Count
+	}
+	return modules
+}
+
+// levelName returns the output name of the level, including the custom
+// level names of this package.
+func levelName(level Level) string {
+	switch level {
+	case TraceLevel:
+		return traceLevelText.String()
+	case FatalLevel:
+		return fatalLevelText.String()
+	default:
+		return level.String()
+	}
+}
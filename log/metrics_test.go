@@ -0,0 +1,60 @@
+package log
+
+import (
+	"bytes"
+	"expvar"
+	"sync"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	ResetMetrics()
+	defer ResetMetrics()
+
+	cfg := DefaultConfig()
+	cfg.Output = &bytes.Buffer{}
+	logger := NewWithConfig(cfg)
+	cpu := logger.Named("cpu")
+
+	logger.Info("info")
+	logger.Debug("filtered debug")
+	cpu.Error("error 1")
+	cpu.Error("error 2")
+
+	expected := []Counter{
+		{Module: "", Level: InfoLevel, Count: 1},
+		{Module: "cpu", Level: ErrorLevel, Count: 2},
+	}
+	assert.Equal(t, expected, Metrics())
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteMetrics(&buf))
+	expectedOutput := `# HELP retrogolib_log_records_total Number of emitted log records.
+# TYPE retrogolib_log_records_total counter
+retrogolib_log_records_total{module="",level="INFO"} 1
+retrogolib_log_records_total{module="cpu",level="ERROR"} 2
+`
+	assert.Equal(t, expectedOutput, buf.String())
+
+	// the expvar value is tested without publishing it, as a name can only
+	// be published once per process and tests can run multiple times
+	assert.Equal(t, `{"":{"INFO":1},"cpu":{"ERROR":2}}`, expvar.Func(metricsByModule).String())
+}
+
+// publishOnce guards the publishing of the test metrics, which would panic
+// when the tests are run multiple times in the same process.
+var publishOnce sync.Once
+
+func TestPublishMetrics(t *testing.T) {
+	publishOnce.Do(func() {
+		PublishMetrics("log_metrics_test")
+	})
+	assert.NotNil(t, expvar.Get("log_metrics_test"))
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	assert.Equal(t, `cpu`, escapeLabelValue("cpu"))
+	assert.Equal(t, `a\\b\"c\nd é`, escapeLabelValue("a\\b\"c\nd é"))
+}
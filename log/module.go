@@ -0,0 +1,102 @@
+package log
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// moduleLevel is a minimum level override for loggers with a matching name.
+type moduleLevel struct {
+	pattern string
+	level   Level
+}
+
+var (
+	moduleLevelsMu sync.Mutex
+	moduleLevels   atomic.Pointer[[]moduleLevel] // immutable slice, replaced on change
+)
+
+// SetLevel sets the minimum level for all loggers whose name matches the
+// pattern, overriding the level of the logger. The pattern is either a logger
+// name like "cpu.z80", a name ending in ".*" like "cpu.*" to match the logger
+// "cpu" and all loggers below it, or "*" to match all named loggers.
+// If multiple patterns match, the most specific one is used.
+func SetLevel(pattern string, level Level) {
+	moduleLevelsMu.Lock()
+	defer moduleLevelsMu.Unlock()
+
+	var levels []moduleLevel
+	if current := moduleLevels.Load(); current != nil {
+		levels = make([]moduleLevel, 0, len(*current)+1)
+		for _, ml := range *current {
+			if ml.pattern != pattern {
+				levels = append(levels, ml)
+			}
+		}
+	}
+
+	levels = append(levels, moduleLevel{pattern: pattern, level: level})
+	moduleLevels.Store(&levels)
+}
+
+// ResetLevel removes the level override for the pattern.
+func ResetLevel(pattern string) {
+	moduleLevelsMu.Lock()
+	defer moduleLevelsMu.Unlock()
+
+	current := moduleLevels.Load()
+	if current == nil {
+		return
+	}
+
+	levels := make([]moduleLevel, 0, len(*current))
+	for _, ml := range *current {
+		if ml.pattern != pattern {
+			levels = append(levels, ml)
+		}
+	}
+	moduleLevels.Store(&levels)
+}
+
+// moduleLevelOverride returns the level override of the most specific
+// pattern that matches the logger name.
+func moduleLevelOverride(name string) (Level, bool) {
+	levels := moduleLevels.Load()
+	if name == "" || levels == nil {
+		return 0, false
+	}
+
+	var level Level
+	bestScore := -1
+	for _, ml := range *levels {
+		score := matchModule(name, ml.pattern)
+		if score > bestScore {
+			bestScore = score
+			level = ml.level
+		}
+	}
+	return level, bestScore >= 0
+}
+
+// matchModule returns the specificity score of the pattern for the logger
+// name or -1 if it does not match. Exact matches are more specific than
+// wildcard matches of the same name.
+func matchModule(name, pattern string) int {
+	if pattern == "*" {
+		return 0
+	}
+
+	prefix, wildcard := strings.CutSuffix(pattern, ".*")
+	if !wildcard {
+		if name == pattern {
+			return 2*len(pattern) + 1
+		}
+		return -1
+	}
+
+	if name == prefix || strings.HasPrefix(name, prefix+".") {
+		return 2 * len(prefix)
+	}
+	return -1
+}
@@ -0,0 +1,43 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestModuleLevel(t *testing.T) {
+	cfg := DefaultConfig()
+	var buf bytes.Buffer
+	cfg.Output = &buf
+	cfg.TimeFormat = "-"
+
+	logger := NewWithConfig(cfg)
+	cpu := logger.Named("cpu").Named("z80")
+	ppu := logger.Named("ppu")
+	assert.Equal(t, "cpu.z80", cpu.Name())
+
+	SetLevel("cpu.*", TraceLevel)
+	defer ResetLevel("cpu.*")
+
+	cpu.Trace("cpu trace")
+	ppu.Debug("ppu debug")
+	logger.Debug("root debug")
+	assert.Equal(t, "TRACE   cpu trace\n", buf.String())
+
+	SetLevel("cpu.z80", ErrorLevel)
+	defer ResetLevel("cpu.z80")
+	buf.Reset()
+	cpu.Warn("cpu warning")
+	assert.Equal(t, "", buf.String())
+}
+
+func TestMatchModule(t *testing.T) {
+	assert.Equal(t, 0, matchModule("cpu", "*"))
+	assert.Equal(t, 6, matchModule("cpu", "cpu.*"))
+	assert.Equal(t, 6, matchModule("cpu.z80", "cpu.*"))
+	assert.Equal(t, -1, matchModule("cpux", "cpu.*"))
+	assert.Equal(t, 15, matchModule("cpu.z80", "cpu.z80"))
+	assert.Equal(t, -1, matchModule("cpu.z80", "cpu"))
+}
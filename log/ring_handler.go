@@ -0,0 +1,223 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+var _ slog.Handler = &RingHandler{}
+
+// RingHandler keeps the last records in memory, which can be dumped on
+// panic or on user request without always writing the log to disk.
+// Records are optionally forwarded to a next handler, which allows adding
+// a ring buffer to an existing handler.
+type RingHandler struct {
+	ring   *ringBuffer
+	next   slog.Handler
+	attrs  []slog.Attr
+	groups []string
+}
+
+// ringBuffer is the record storage that is shared by all derived handlers.
+type ringBuffer struct {
+	mu      sync.Mutex
+	records []slog.Record
+	start   int // index of the oldest record
+	count   int
+}
+
+// NewRingHandler returns a new ring buffer handler that keeps the last size
+// records. If next is not nil, all records are also passed to it.
+func NewRingHandler(size int, next slog.Handler) *RingHandler {
+	return &RingHandler{
+		ring: &ringBuffer{
+			records: make([]slog.Record, max(size, 1)),
+		},
+		next: next,
+	}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+// The ring buffer keeps records of all levels that the logger emits.
+func (h *RingHandler) Enabled(_ context.Context, _ slog.Level) bool {
+	return true
+}
+
+// Handle stores the record in the ring buffer and passes it to the next handler.
+func (h *RingHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.ring.add(h.resolve(r))
+
+	if h.next == nil || !h.next.Enabled(ctx, r.Level) {
+		return nil
+	}
+	if err := h.next.Handle(ctx, r); err != nil {
+		return fmt.Errorf("handling record: %w", err)
+	}
+	return nil
+}
+
+// WithAttrs returns a new Handler whose attributes consist of
+// both the receiver's attributes and the arguments.
+// nolint: ireturn
+func (h *RingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := h.clone()
+	if len(h.groups) > 0 {
+		attrs = []slog.Attr{groupAttrs(h.groups, attrs)}
+	}
+	handler.attrs = append(handler.attrs, attrs...)
+	if h.next != nil {
+		handler.next = h.next.WithAttrs(attrs)
+	}
+	return handler
+}
+
+// WithGroup returns a new Handler with the given group appended to
+// the receiver's existing groups.
+// nolint: ireturn
+func (h *RingHandler) WithGroup(name string) slog.Handler {
+	handler := h.clone()
+	handler.groups = append(handler.groups, name)
+	if h.next != nil {
+		handler.next = h.next.WithGroup(name)
+	}
+	return handler
+}
+
+// Records returns a copy of the buffered records, ordered from oldest to newest.
+func (h *RingHandler) Records() []slog.Record {
+	return h.ring.snapshot()
+}
+
+// Last returns a copy of the newest count buffered records, ordered from
+// oldest to newest.
+func (h *RingHandler) Last(count int) []slog.Record {
+	records := h.ring.snapshot()
+	if count >= 0 && count < len(records) {
+		records = records[len(records)-count:]
+	}
+	return records
+}
+
+// Dump writes all buffered records in the console handler format to the writer.
+func (h *RingHandler) Dump(w io.Writer) error {
+	return dumpRecords(w, h.ring.snapshot(), DefaultTimeFormat)
+}
+
+// Reset removes all buffered records.
+func (h *RingHandler) Reset() {
+	h.ring.mu.Lock()
+	h.ring.start = 0
+	h.ring.count = 0
+	clear(h.ring.records)
+	h.ring.mu.Unlock()
+}
+
+func (h *RingHandler) clone() *RingHandler {
+	return &RingHandler{
+		ring:   h.ring,
+		next:   h.next,
+		attrs:  append([]slog.Attr(nil), h.attrs...),
+		groups: append([]string(nil), h.groups...),
+	}
+}
+
+// resolve returns a copy of the record that contains the attributes and
+// groups of the handler, so that it can be formatted standalone later.
+// LogValuer values are resolved, which stores the values at the time of
+// logging instead of the time of the dump.
+func (h *RingHandler) resolve(r slog.Record) slog.Record {
+	resolved := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	for _, a := range h.attrs {
+		resolved.AddAttrs(resolveAttr(a))
+	}
+
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, resolveAttr(a))
+		return true
+	})
+	if len(attrs) > 0 {
+		if len(h.groups) > 0 {
+			attrs = []slog.Attr{groupAttrs(h.groups, attrs)}
+		}
+		resolved.AddAttrs(attrs...)
+	}
+	return resolved
+}
+
+// resolveAttr resolves the LogValuer values of the attribute, including the
+// members of groups.
+func resolveAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return a
+	}
+
+	members := a.Value.Group()
+	resolved := make([]slog.Attr, len(members))
+	for i, member := range members {
+		resolved[i] = resolveAttr(member)
+	}
+	a.Value = slog.GroupValue(resolved...)
+	return a
+}
+
+// dumpRecords writes the records in the console handler format to the writer.
+func dumpRecords(w io.Writer, records []slog.Record, timeFormat string) error {
+	console := NewConsoleHandler(w, &ConsoleHandlerOptions{
+		SlogOptions: &slog.HandlerOptions{
+			ReplaceAttr: ReplaceLevelName,
+		},
+		TimeFormat: timeFormat,
+	})
+
+	for _, r := range records {
+		if err := console.Handle(context.Background(), r); err != nil {
+			return fmt.Errorf("dumping record: %w", err)
+		}
+	}
+	return nil
+}
+
+// groupAttrs wraps the attributes in nested groups.
+func groupAttrs(groups []string, attrs []slog.Attr) slog.Attr {
+	values := make([]any, len(attrs))
+	for i, a := range attrs {
+		values[i] = a
+	}
+
+	attr := slog.Group(groups[len(groups)-1], values...)
+	for i := len(groups) - 2; i >= 0; i-- {
+		attr = slog.Group(groups[i], attr)
+	}
+	return attr
+}
+
+func (b *ringBuffer) add(r slog.Record) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	size := len(b.records)
+	if b.count < size {
+		b.records[(b.start+b.count)%size] = r
+		b.count++
+		return
+	}
+
+	b.records[b.start] = r
+	b.start = (b.start + 1) % size
+}
+
+func (b *ringBuffer) snapshot() []slog.Record {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	records := make([]slog.Record, b.count)
+	for i := range b.count {
+		records[i] = b.records[(b.start+i)%len(b.records)].Clone()
+	}
+	return records
+}
@@ -0,0 +1,65 @@
+package log
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestRingHandler(t *testing.T) {
+	var output bytes.Buffer
+	next := NewConsoleHandler(&output, &ConsoleHandlerOptions{
+		SlogOptions: &slog.HandlerOptions{ReplaceAttr: ReplaceLevelName},
+		TimeFormat:  "-",
+	})
+	ring := NewRingHandler(2, next)
+
+	cfg := DefaultConfig()
+	cfg.Handler = ring
+	logger := NewWithConfig(cfg)
+
+	logger.Info("first")
+	logger.Info("second")
+	logger.Warn("third", String("key", "value"))
+	assert.Len(t, ring.Records(), 2)
+	assert.Equal(t, "INFO    first\nINFO    second\nWARN    third {\"key\":\"value\"}\n", output.String())
+
+	var dump bytes.Buffer
+	assert.NoError(t, ring.Dump(&dump))
+	assert.True(t, bytes.Contains(dump.Bytes(), []byte("INFO    second\n")))
+	assert.True(t, bytes.Contains(dump.Bytes(), []byte("WARN    third {\"key\":\"value\"}\n")))
+	assert.False(t, bytes.Contains(dump.Bytes(), []byte("first")))
+
+	ring.Reset()
+	assert.Len(t, ring.Records(), 0)
+}
+
+// pointerValuer is a lazy log value that returns the current value of the
+// string that it points to.
+type pointerValuer struct {
+	value *string
+}
+
+func (v pointerValuer) LogValue() slog.Value {
+	return slog.StringValue(*v.value)
+}
+
+func TestRingHandlerResolvesValues(t *testing.T) {
+	ring := NewRingHandler(2, nil)
+	cfg := DefaultConfig()
+	cfg.Handler = ring
+	logger := NewWithConfig(cfg)
+
+	value := "logged"
+	logger.Info("lazy", slog.Any("value", pointerValuer{&value}),
+		slog.Group("group", slog.Any("member", pointerValuer{&value})))
+	value = "changed"
+
+	var dump bytes.Buffer
+	assert.NoError(t, ring.Dump(&dump))
+	assert.True(t, bytes.Contains(dump.Bytes(), []byte(`"value":"logged"`)), dump.String())
+	assert.True(t, bytes.Contains(dump.Bytes(), []byte(`"member":"logged"`)), dump.String())
+	assert.False(t, bytes.Contains(dump.Bytes(), []byte("changed")), dump.String())
+}
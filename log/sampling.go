@@ -0,0 +1,127 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// maxSampledMessages is the maximum amount of different messages that a
+// sampler keeps state for, which bounds the memory usage for loggers that
+// log formatted or otherwise dynamic messages.
+const maxSampledMessages = 1024
+
+// sampler decides whether a record that passed the level check gets emitted.
+type sampler interface {
+	allow(level Level, msg string) bool
+}
+
+// WithSampling returns a child logger that emits only the first and then
+// every n-th record of every message, which is useful for hot paths like
+// warnings that are logged on every frame or executed instruction.
+// Records at ErrorLevel and above are never sampled. The counts are reset
+// when more than 1024 different messages are logged.
+func (l *Logger) WithSampling(n int) *Logger {
+	return l.withSampler(&countSampler{
+		n:      uint64(max(n, 1)),
+		counts: map[string]uint64{},
+	})
+}
+
+// WithRateLimit returns a child logger that emits at most limit records of
+// every message per interval. Records at ErrorLevel and above are never
+// rate limited. Windows of messages that were not logged during the last
+// interval are removed when more than 1024 different messages are logged.
+func (l *Logger) WithRateLimit(limit int, interval time.Duration) *Logger {
+	return l.withSampler(&rateSampler{
+		limit:    max(limit, 1),
+		interval: interval,
+		windows:  map[string]*rateWindow{},
+		now:      time.Now,
+	})
+}
+
+func (l *Logger) withSampler(s sampler) *Logger {
+	return &Logger{
+		logger:     l.logger,
+		handler:    l.handler,
+		callerInfo: l.callerInfo,
+		level:      l.level,
+		name:       l.name,
+		sampler:    s,
+	}
+}
+
+// countSampler emits every n-th record of a message.
+type countSampler struct {
+	mu     sync.Mutex
+	n      uint64
+	counts map[string]uint64
+}
+
+func (s *countSampler) allow(level Level, msg string) bool {
+	if level >= ErrorLevel {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count, ok := s.counts[msg]
+	if !ok && len(s.counts) >= maxSampledMessages {
+		clear(s.counts)
+	}
+	s.counts[msg] = count + 1
+	return count%s.n == 0
+}
+
+// rateSampler emits a limited number of records of a message per interval.
+type rateSampler struct {
+	mu       sync.Mutex
+	limit    int
+	interval time.Duration
+	windows  map[string]*rateWindow
+	now      func() time.Time // used in unit tests
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func (s *rateSampler) allow(level Level, msg string) bool {
+	if level >= ErrorLevel {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	window, ok := s.windows[msg]
+	if !ok || now.Sub(window.start) >= s.interval {
+		if !ok && len(s.windows) >= maxSampledMessages {
+			s.expireWindows(now)
+		}
+		s.windows[msg] = &rateWindow{start: now, count: 1}
+		return true
+	}
+
+	if window.count >= s.limit {
+		return false
+	}
+	window.count++
+	return true
+}
+
+// expireWindows removes all windows whose interval has passed. If all
+// windows are still active, all of them are removed to bound the memory.
+func (s *rateSampler) expireWindows(now time.Time) {
+	for msg, window := range s.windows {
+		if now.Sub(window.start) >= s.interval {
+			delete(s.windows, msg)
+		}
+	}
+	if len(s.windows) >= maxSampledMessages {
+		clear(s.windows)
+	}
+}
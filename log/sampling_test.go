@@ -0,0 +1,85 @@
+package log
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestWithSampling(t *testing.T) {
+	cfg := DefaultConfig()
+	var buf bytes.Buffer
+	cfg.Output = &buf
+	cfg.TimeFormat = "-"
+
+	logger := NewWithConfig(cfg).WithSampling(3)
+	for range 7 {
+		logger.Warn("unsupported opcode")
+	}
+	logger.Info("other")
+	logger.Error("failure")
+	logger.Error("failure")
+
+	expected := []string{
+		"WARN    unsupported opcode",
+		"WARN    unsupported opcode",
+		"WARN    unsupported opcode",
+		"INFO    other",
+		"ERROR   failure",
+		"ERROR   failure",
+		"",
+	}
+	assert.Equal(t, strings.Join(expected, "\n"), buf.String())
+}
+
+func TestWithRateLimit(t *testing.T) {
+	cfg := DefaultConfig()
+	var buf bytes.Buffer
+	cfg.Output = &buf
+	cfg.TimeFormat = "-"
+
+	now := time.Unix(0, 0)
+	logger := NewWithConfig(cfg).WithRateLimit(2, time.Second)
+	logger.sampler.(*rateSampler).now = func() time.Time { return now }
+	child := logger.Named("cpu")
+
+	for range 5 {
+		child.Warn("stuck")
+	}
+	assert.Equal(t, 2, strings.Count(buf.String(), "stuck"))
+
+	now = now.Add(time.Second)
+	child.Warn("stuck")
+	assert.Equal(t, 3, strings.Count(buf.String(), "stuck"))
+}
+
+func TestSamplerBounded(t *testing.T) {
+	counts := &countSampler{n: 2, counts: map[string]uint64{}}
+	for i := range 3 * maxSampledMessages {
+		counts.allow(WarnLevel, strconv.Itoa(i))
+	}
+	assert.True(t, len(counts.counts) <= maxSampledMessages)
+
+	now := time.Unix(0, 0)
+	rates := &rateSampler{limit: 1, interval: time.Second, windows: map[string]*rateWindow{},
+		now: func() time.Time { return now }}
+	for i := range maxSampledMessages {
+		rates.allow(WarnLevel, strconv.Itoa(i))
+	}
+	assert.Len(t, rates.windows, maxSampledMessages)
+
+	// stale windows get expired
+	now = now.Add(time.Second)
+	assert.True(t, rates.allow(WarnLevel, "new"))
+	assert.Len(t, rates.windows, 1)
+
+	// active windows are removed if the limit is reached
+	for i := range 2 * maxSampledMessages {
+		rates.allow(WarnLevel, strconv.Itoa(i))
+	}
+	assert.True(t, len(rates.windows) <= maxSampledMessages)
+}
@@ -0,0 +1,84 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// Option defines a logger parameter that modifies the config used by New.
+type Option func(*Config)
+
+// WithHandler sets the handler that processes the log records, it can be
+// any slog.Handler of the application logging pipeline.
+func WithHandler(handler slog.Handler) func(*Config) {
+	return func(cfg *Config) {
+		cfg.Handler = handler
+	}
+}
+
+// WithLevel sets the minimum enabled log level.
+func WithLevel(level Level) func(*Config) {
+	return func(cfg *Config) {
+		cfg.Level = level
+	}
+}
+
+// WithCallerInfo adds the source code position of the log statement to the records.
+func WithCallerInfo() func(*Config) {
+	return func(cfg *Config) {
+		cfg.CallerInfo = true
+	}
+}
+
+// Slog returns a standard library logger that logs through this logger. The
+// level of the logger, module level overrides, sampling and metrics apply
+// to records of the returned logger as well.
+func (l *Logger) Slog() *slog.Logger {
+	return slog.New(&loggerHandler{
+		logger:  l,
+		handler: l.handler,
+	})
+}
+
+// loggerHandler adapts a Logger to the slog.Handler interface.
+type loggerHandler struct {
+	logger  *Logger
+	handler slog.Handler
+}
+
+// Enabled reports whether the logger emits records at the given level.
+func (h *loggerHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.enabled(ctx, level)
+}
+
+// Handle passes the record to the handler of the logger if the sampler
+// of the logger allows it.
+func (h *loggerHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.logger.sampler != nil && !h.logger.sampler.allow(r.Level, r.Message) {
+		return nil
+	}
+	countRecord(h.logger.name, r.Level)
+	if err := h.handler.Handle(ctx, r); err != nil {
+		return fmt.Errorf("handling record: %w", err)
+	}
+	return nil
+}
+
+// WithAttrs returns a new handler with the given attributes.
+// nolint: ireturn
+func (h *loggerHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &loggerHandler{
+		logger:  h.logger,
+		handler: h.handler.WithAttrs(attrs),
+	}
+}
+
+// WithGroup returns a new handler with the given group.
+// nolint: ireturn
+func (h *loggerHandler) WithGroup(name string) slog.Handler {
+	return &loggerHandler{
+		logger:  h.logger,
+		handler: h.handler.WithGroup(name),
+	}
+}
@@ -0,0 +1,40 @@
+package log
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/retroenv/retrogolib/assert"
+)
+
+func TestWithHandler(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		Level: TraceLevel,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	logger := New(WithHandler(handler), WithLevel(InfoLevel))
+	logger.Debug("filtered")
+	logger.Info("started", String("system", "nes"))
+	assert.Equal(t, `{"level":"INFO","msg":"started","system":"nes"}`+"\n", buf.String())
+}
+
+func TestLoggerSlog(t *testing.T) {
+	cfg := DefaultConfig()
+	var buf bytes.Buffer
+	cfg.Output = &buf
+	cfg.TimeFormat = "-"
+	cfg.Level = InfoLevel
+
+	logger := NewWithConfig(cfg).Slog()
+	logger.Debug("filtered")
+	logger.Warn("jam", "cpu", "6502")
+	assert.Equal(t, "WARN    jam {\"cpu\":\"6502\"}\n", buf.String())
+}